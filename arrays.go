@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"syscall/js"
+)
+
+// 値がJavaScriptの配列かどうかを判定する
+func isJSArray(val js.Value) bool {
+	return hasType(val, js.TypeObject) && val.InstanceOf(js.Global().Get("Array"))
+}
+
+// JavaScriptから呼び出される sumArray 関数
+// 1つの配列を受け取り、その要素の整数の合計を返す
+//
+// 疎な配列の穴や undefined の要素は「値が無い」ものとして読み飛ばす。
+// null や文字列など、それ以外の整数に変換できない要素はインデックス付きのエラーにする。
+func sumArray(this js.Value, args []js.Value) interface{} {
+	arr := args[0]
+	total := 0
+	for i := 0; i < arr.Length(); i++ {
+		elem := arr.Index(i)
+		if elem.IsUndefined() {
+			continue
+		}
+		num, err := 安全にIntに変換(elem)
+		if err != nil {
+			return newJSError(errCodeOf(err), fmt.Sprintf("Element at index %d %s", i, errMessageOf(err)))
+		}
+		total += num
+	}
+	return js.ValueOf(total)
+}
+
+// JavaScriptの数値配列をGoの []float64 に変換する
+// 各要素は 安全にFloatに変換 で検証し、失敗した場合は要素のインデックスをエラーに含める
+func jsArrayToFloats(val js.Value) ([]float64, error) {
+	if !isJSArray(val) {
+		return nil, newError(errCodeNotAnArray, "is not an array")
+	}
+	nums := make([]float64, val.Length())
+	for i := range nums {
+		num, err := 安全にFloatに変換(val.Index(i))
+		if err != nil {
+			return nil, newError(errCodeOf(err), "element at index %d %s", i, errMessageOf(err))
+		}
+		nums[i] = num
+	}
+	return nums, nil
+}
+
+// JavaScriptから呼び出される sort 関数
+// 数値の配列を並べ替えた新しい配列を返す。元の配列は変更しない
+// 省略可能な第2引数で "asc" (既定値、昇順) または "desc" (降順) を指定する
+func sortNumbers(this js.Value, args []js.Value) interface{} {
+	desc := false
+	if order, ok := optionalArg(args, 1); ok {
+		switch order.String() {
+		case "asc":
+		case "desc":
+			desc = true
+		default:
+			return newJSError(errCodeInvalidOption, fmt.Sprintf("Unknown sort order %q: expected \"asc\" or \"desc\"", order.String()))
+		}
+	}
+
+	nums, err := jsArrayToFloats(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	sort.Float64s(nums)
+	if desc {
+		for i, j := 0, len(nums)-1; i < j; i, j = i+1, j-1 {
+			nums[i], nums[j] = nums[j], nums[i]
+		}
+	}
+	return goToJS(nums)
+}
+
+// JavaScriptから呼び出される chunk 関数
+// 配列を size 個ずつの配列に分けた配列を返す。最後の配列は size 個より少なくなることがある
+//
+//	goWasm.chunk([1, 2, 3, 4, 5], 2) // => [[1, 2], [3, 4], [5]]
+//
+// 要素は型を問わずそのまま (オブジェクトは同じ参照のまま) コピーする。元の配列は変更しない
+func chunk(this js.Value, args []js.Value) interface{} {
+	arr := args[0]
+	size, err := 安全な整数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	if size <= 0 {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must be positive (got %d)", size))
+	}
+
+	n := arr.Length()
+	arrayCtor := js.Global().Get("Array")
+	chunks := arrayCtor.New((n + size - 1) / size)
+	for start := 0; start < n; start += size {
+		end := min(start+size, n)
+		c := arrayCtor.New(end - start)
+		for i := start; i < end; i++ {
+			c.SetIndex(i-start, arr.Index(i))
+		}
+		chunks.SetIndex(start/size, c)
+	}
+	return chunks
+}
+
+// JavaScriptから呼び出される flatten 関数
+// 入れ子になった配列を depth 段まで展開した1つの配列を返す。depth が負の場合はすべての段を展開する
+//
+//	goWasm.flatten([1, [2, [3, [4]]], "a"], 1)  // => [1, 2, [3, [4]], "a"]
+//	goWasm.flatten([1, [2, [3, [4]]], "a"], -1) // => [1, 2, 3, 4, "a"]
+//
+// 配列以外の要素は型を問わずそのままの順序で残す。Array.prototype.flat と異なり、疎な配列の穴は undefined として残る。
+// 入れ子が maxConvertDepth 段を超える場合は循環参照の可能性があるため TOO_DEEP を返す。
+func flatten(this js.Value, args []js.Value) interface{} {
+	depth, err := 安全な整数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	if depth < 0 || depth > maxConvertDepth {
+		depth = maxConvertDepth + 1
+	}
+	var out []js.Value
+	if err := flattenInto(&out, args[0], depth, 0); err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	result := js.Global().Get("Array").New(len(out))
+	for i, v := range out {
+		result.SetIndex(i, v)
+	}
+	return result
+}
+
+// arr の要素を out に追加し、配列の要素は残りの段数 depth がある限り再帰的に展開する
+func flattenInto(out *[]js.Value, arr js.Value, depth, level int) error {
+	if level > maxConvertDepth {
+		return newError(errCodeTooDeep, "nesting exceeds %d levels (circular reference?)", maxConvertDepth)
+	}
+	for i := 0; i < arr.Length(); i++ {
+		elem := arr.Index(i)
+		if depth > 0 && isJSArray(elem) {
+			if err := flattenInto(out, elem, depth-1, level+1); err != nil {
+				return err
+			}
+			continue
+		}
+		*out = append(*out, elem)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// JavaScriptの Promise を作成し、work をgoroutine上で実行する
+//
+// Promise コンストラクタに渡す executor は new Promise(...) の中で同期的に呼ばれるため、
+// そこで受け取った resolve/reject をそのままgoroutineへ渡す。
+// executor の js.Func は Promise の作成が終わった時点で不要になるので、すぐに Release する。
+//
+// work は resolve か reject のどちらかを呼んだ時点で return すること。
+// goroutine は work の終了とともに必ず終わるため、Promise が await されなくてもリークしない
+// (誰も待っていない Promise を resolve しても何も起きないだけである)。
+func newPromise(work func(resolve, reject js.Value)) js.Value {
+	executor := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolve, reject := args[0], args[1]
+		go func() {
+			// goroutine内のpanicは safeWrap では回収できないため、ここで reject に変換する
+			defer func() {
+				if r := recover(); r != nil {
+					logf(levelError, "Recovered from panic in Go async callback: %v", r)
+					rejectWithError(reject, errCodePanic, fmt.Sprintf("Go panic: %v", r))
+				}
+			}()
+			work(resolve, reject)
+		}()
+		return nil
+	})
+	defer executor.Release()
+	return js.Global().Get("Promise").New(executor)
+}
+
+// Promise を code 付きの Error で reject する
+// 同期関数が返す Error と同じ形 (message と code) にそろえ、呼び出し側が同期・非同期の失敗を同じように扱えるようにする
+//
+// code には errors.go のエラーコードを使う。主な分類は次のとおり
+//
+//	引数の検証: INVALID_ARG_COUNT, NOT_A_NUMBER, NOT_AN_INTEGER, NOT_FINITE, OUT_OF_RANGE など
+//	オーバーフロー: OVERFLOW
+//	タイムアウト: TIMEOUT
+func rejectWithError(reject js.Value, code, msg string) {
+	reject.Invoke(newJSError(code, msg))
+}
+
+// JavaScriptから呼び出される addAsync 関数
+// add と同じ計算を行い、結果を Promise で返す
+func addAsync(this js.Value, args []js.Value) interface{} {
+	return newPromise(func(resolve, reject js.Value) {
+		arg1, arg2, err := 二つの整数引数を取得(args)
+		if err != nil {
+			rejectWithError(reject, errCodeOf(err), errMessageOf(err))
+			return
+		}
+		resolve.Invoke(arg1 + arg2)
+	})
+}
+
+// Promise (または then を持つ値) が決着するまで待ち、履行された値か拒否の理由を返す
+// Promise でない値はそのまま履行された値として扱う
+//
+// JavaScriptの Promise の決着はイベントループ上で起きるため、これはgoroutine上からのみ呼ぶこと。
+// js.FuncOf のコールバック内で呼ぶと、決着を待つ間イベントループが止まりデッドロックする。
+func awaitPromise(p js.Value) (value js.Value, reason js.Value, ok bool) {
+	if !hasType(p, js.TypeObject) || !hasType(p.Get("then"), js.TypeFunction) {
+		return p, js.Undefined(), true
+	}
+	type settled struct {
+		val js.Value
+		ok  bool
+	}
+	ch := make(chan settled, 1)
+	settle := func(ok bool) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			val := js.Undefined()
+			if len(args) > 0 {
+				val = args[0]
+			}
+			ch <- settled{val, ok}
+			return nil
+		})
+	}
+	onFulfilled, onRejected := settle(true), settle(false)
+	defer onFulfilled.Release()
+	defer onRejected.Release()
+	p.Call("then", onFulfilled, onRejected)
+
+	r := <-ch
+	if r.ok {
+		return r.val, js.Undefined(), true
+	}
+	return js.Undefined(), r.val, false
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// batch から呼び出せない関数
+// batch 自身の再帰呼び出しと、途中でモジュールを停止させる shutdown は除外する
+var batchExcluded = map[string]bool{
+	"batch":    true,
+	"shutdown": true,
+}
+
+// JavaScriptから呼び出される batch 関数
+// 操作の配列をまとめて受け取り、各操作の結果を同じ順序の配列で返す
+// JavaScriptとWasmの境界をまたぐ回数を1回にまとめ、小さな計算を大量に行う場合のオーバーヘッドを減らす
+//
+//	goWasm.batch([{ op: "add", args: [1, 2] }, { op: "reverse", args: ["abc"] }])
+//	// => [3, "cba"]
+//
+// op には goWasm に登録された関数名を指定する。args は省略すると引数なしで呼び出す。
+// 不明な op や個々の操作の失敗はその要素だけが Error になり、バッチ全体は失敗しない。
+func batch(this js.Value, args []js.Value) interface{} {
+	ops := args[0]
+	results := js.Global().Get("Array").New(ops.Length())
+	for i := 0; i < ops.Length(); i++ {
+		results.SetIndex(i, runBatchOp(ops.Index(i), i))
+	}
+	return results
+}
+
+// batch の1要素分の操作を実行する
+func runBatchOp(desc js.Value, index int) js.Value {
+	if !hasType(desc, js.TypeObject) || isJSArray(desc) {
+		return newJSError(errCodeNotAnObject, fmt.Sprintf("Operation at index %d is not an object", index))
+	}
+	opVal := desc.Get("op")
+	if !hasType(opVal, js.TypeString) {
+		return newJSError(errCodeNotAString, fmt.Sprintf("Operation at index %d has no string \"op\" property", index))
+	}
+	op := opVal.String()
+	fn, ok := callbacks[op]
+	if !ok || batchExcluded[op] {
+		return newJSError(errCodeUnknownOp, fmt.Sprintf("Unknown operation %q at index %d", op, index))
+	}
+
+	var opArgs []js.Value
+	argsVal := desc.Get("args")
+	switch {
+	case argsVal.IsUndefined():
+	case isJSArray(argsVal):
+		opArgs = make([]js.Value, argsVal.Length())
+		for j := range opArgs {
+			opArgs[j] = argsVal.Index(j)
+		}
+	default:
+		return newJSError(errCodeNotAnArray, fmt.Sprintf("Operation at index %d has a non-array \"args\" property", index))
+	}
+	return goToJS(fn(js.Undefined(), opArgs))
+}
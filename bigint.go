@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math/big"
+	"syscall/js"
+)
+
+// typeof で BigInt を判定するJavaScriptの関数。呼び出しのたびに作らないよう一度だけ作成する
+var bigIntCheck = js.Global().Get("Function").New("v", "return typeof v === 'bigint'")
+
+// 値がJavaScriptの BigInt かどうかを判定する
+// syscall/js は BigInt に対応する js.Type を持たず、BigInt に対して Type() を呼ぶと "bad type flag" で panic する。
+// そのため Type() を使わずに、JavaScript側の typeof で判定する
+func isBigInt(val js.Value) bool {
+	return bigIntCheck.Invoke(val).Bool()
+}
+
+// 値の型が t かどうかを判定する
+// BigInt の可能性がある値に Type() を直接呼ぶと panic するため、型の判定はすべてこれを通す。BigInt はどの t にも一致しない
+func hasType(val js.Value, t js.Type) bool {
+	return !isBigInt(val) && val.Type() == t
+}
+
+// BigInt または安全な整数の Number を *big.Int に変換するヘルパー関数
+// BigInt は String() を経由して10進文字列にしてから math/big で読み込むため、精度は失われない
+func 安全にBigIntに変換(val js.Value) (*big.Int, error) {
+	if err := 値の有無を確認(val); err != nil {
+		return nil, err
+	}
+	if isBigInt(val) {
+		s := js.Global().Get("String").Invoke(val).String()
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, newError(errCodeNotABigInt, "could not be parsed as a BigInt (%q)", s)
+		}
+		return n, nil
+	}
+	if hasType(val, js.TypeNumber) {
+		n, err := 安全な整数に変換(val)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(n)), nil
+	}
+	return nil, newError(errCodeNotABigInt, "is not a BigInt (got %s)", typeName(val))
+}
+
+// *big.Int をJavaScriptの BigInt に変換する
+// Goから直接 BigInt を作る手段はないため、10進文字列を BigInt(string) に渡して作成する
+func bigIntToJS(n *big.Int) js.Value {
+	return js.Global().Get("BigInt").Invoke(n.String())
+}
+
+// JavaScriptから呼び出される addBig 関数
+// 2つの BigInt (または安全な整数の Number) を精度を失わずに加算し、BigInt で返す
+func addBig(this js.Value, args []js.Value) interface{} {
+	arg1, err := 安全にBigIntに変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	arg2, err := 安全にBigIntに変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	return bigIntToJS(new(big.Int).Add(arg1, arg2))
+}
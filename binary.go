@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// 値がJavaScriptの Uint8Array かどうかを判定する
+func isUint8Array(val js.Value) bool {
+	return hasType(val, js.TypeObject) && val.InstanceOf(js.Global().Get("Uint8Array"))
+}
+
+// Uint8Array の内容をGoのバイト列にコピーする
+func uint8ArrayToBytes(val js.Value) []byte {
+	buf := make([]byte, val.Get("length").Int())
+	js.CopyBytesToGo(buf, val)
+	return buf
+}
+
+// 文字列または Uint8Array をバイト列として取り出す
+// 文字列はUTF-8でエンコードされたバイト列として扱う
+func bytesFromJS(val js.Value) ([]byte, error) {
+	if err := 値の有無を確認(val); err != nil {
+		return nil, err
+	}
+	switch {
+	case hasType(val, js.TypeString):
+		return []byte(val.String()), nil
+	case isUint8Array(val):
+		return uint8ArrayToBytes(val), nil
+	default:
+		return nil, newError(errCodeWrongType, "is not a string or Uint8Array (got %s)", typeName(val))
+	}
+}
+
+// Goのバイト列を新しい Uint8Array にコピーして返す
+func bytesToUint8Array(data []byte) js.Value {
+	arr := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(arr, data)
+	return arr
+}
+
+// xorBytes が一度にGo側へコピーするバイト数
+// 大きな入力でも全体を一度にGoのメモリへ複製しないよう、この大きさのバッファを使い回して少しずつ処理する
+const xorChunkSize = 1 << 20
+
+// JavaScriptから呼び出される xorBytes 関数
+// Uint8Array の各バイトと keyByte (0から255) の排他的論理和をとった新しい Uint8Array を返す。元の配列は変更しない
+//
+//	goWasm.xorBytes(new Uint8Array([1, 2, 3]), 0xff) // => Uint8Array [254, 253, 252]
+func xorBytes(this js.Value, args []js.Value) interface{} {
+	data := args[0]
+	key, err := 安全な整数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	if key < 0 || key > 255 {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must be between 0 and 255 (got %d)", key))
+	}
+
+	n := data.Get("length").Int()
+	out := js.Global().Get("Uint8Array").New(n)
+	buf := make([]byte, min(n, xorChunkSize))
+	for off := 0; off < n; off += len(buf) {
+		chunk := buf[:min(len(buf), n-off)]
+		js.CopyBytesToGo(chunk, data.Call("subarray", off, off+len(chunk)))
+		for i := range chunk {
+			chunk[i] ^= byte(key)
+		}
+		js.CopyBytesToJS(out.Call("subarray", off, off+len(chunk)), chunk)
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+// 結果キャッシュに保持する最大件数
+const resultCacheSize = 256
+
+// 純粋関数の結果を保持するLRUキャッシュ
+//
+// exportedFunc.cacheable が true の関数だけが対象で、キーは「関数名 + 引数を文字列化したもの」。
+// 件数が resultCacheSize を超えると、最も長く参照されていないエントリから破棄する (LRU)。
+// 参照 (ヒット) したエントリは最新として扱われ、破棄されにくくなる。
+// Error を返した呼び出しはキャッシュしない。
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // 先頭ほど最近使われたエントリ
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key   string
+	value js.Value
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *lruCache) get(key string) (js.Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return js.Undefined(), false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).value, true
+}
+
+func (c *lruCache) put(key string, value js.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// すべてのエントリを破棄し、破棄した件数を返す
+func (c *lruCache) clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := c.order.Len()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	return n
+}
+
+var resultCache = newLRUCache(resultCacheSize)
+
+// 引数からキャッシュのキーを作る
+// 数値・文字列・真偽値・BigInt 以外の引数を含む場合は、内容の同一性を安く判定できないため ok が false になる
+func cacheKey(name string, args []js.Value) (key string, ok bool) {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, arg := range args {
+		b.WriteByte(0)
+		switch {
+		case hasType(arg, js.TypeNumber):
+			b.WriteString("n:" + strconv.FormatFloat(arg.Float(), 'g', -1, 64))
+		case hasType(arg, js.TypeString):
+			b.WriteString("s:" + arg.String())
+		case hasType(arg, js.TypeBoolean):
+			b.WriteString("b:" + strconv.FormatBool(arg.Bool()))
+		case isBigInt(arg):
+			b.WriteString("i:" + js.Global().Get("String").Invoke(arg).String())
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// cacheable な関数の結果をキャッシュするラッパー
+// キャッシュされた値はそのまま返されるため、対象の関数は number / string / BigInt などの
+// 変更できない値を返すものに限ること (オブジェクトを返すと呼び出し元での変更が共有されてしまう)
+func withCache(name string, fn callback) callback {
+	return func(this js.Value, args []js.Value) interface{} {
+		key, ok := cacheKey(name, args)
+		if !ok {
+			return fn(this, args)
+		}
+		if v, hit := resultCache.get(key); hit {
+			return v
+		}
+		result := goToJS(fn(this, args))
+		if !result.InstanceOf(js.Global().Get("Error")) {
+			resultCache.put(key, result)
+		}
+		return result
+	}
+}
+
+// JavaScriptから呼び出される clearCache 関数
+// 結果キャッシュをすべて破棄し、破棄した件数を返す
+func clearCache(this js.Value, args []js.Value) interface{} {
+	return js.ValueOf(resultCache.clear())
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+// 色の成分を 0 から 255 の実数で持つRGB値
+// 変換の途中では丸めず、JavaScriptへ返すときに出力形式に合わせて丸める
+type rgbColor struct {
+	r, g, b float64
+}
+
+// JavaScriptから呼び出される colorConvert 関数
+// 色を fromFormat の形式から toFormat の形式に変換する。形式は次の3つ
+//
+//	"hex" 文字列 "#rrggbb" または "#rgb" (# は省略可、大文字小文字を区別しない)。出力は小文字の "#rrggbb"
+//	"rgb" オブジェクト { r, g, b }。各成分は 0 から 255 で、出力は整数に丸める
+//	"hsl" オブジェクト { h, s, l }。h は 0 から 360 (度)、s と l は 0 から 100 (%) で、出力は小数点以下1桁に丸める
+//
+//	goWasm.colorConvert("#3366cc", "hex", "hsl")                // => { h: 220, s: 60, l: 50 }
+//	goWasm.colorConvert({ h: 220, s: 60, l: 50 }, "hsl", "rgb") // => { r: 51, g: 102, b: 204 }
+//
+// 丸めは0.5ちょうどを0から遠い方へ丸める。不正な16進表記は INVALID_COLOR、範囲外の成分は OUT_OF_RANGE になる。
+func colorConvert(this js.Value, args []js.Value) interface{} {
+	from, to := args[1].String(), args[2].String()
+	for i, format := range []string{from, to} {
+		if format != "hex" && format != "rgb" && format != "hsl" {
+			return newJSError(errCodeInvalidOption, fmt.Sprintf("Argument %d has unknown color format %q: expected \"hex\", \"rgb\" or \"hsl\"", i+2, format))
+		}
+	}
+	c, err := colorFromJS(args[0], from)
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	return colorToJS(c, to)
+}
+
+// format の形式の色を rgbColor に変換する
+func colorFromJS(val js.Value, format string) (rgbColor, error) {
+	if format == "hex" {
+		if !hasType(val, js.TypeString) {
+			return rgbColor{}, newError(errCodeNotAString, "is not a hex color string (got %s)", typeName(val))
+		}
+		return parseHexColor(val.String())
+	}
+	if !hasType(val, js.TypeObject) || isJSArray(val) {
+		return rgbColor{}, newError(errCodeNotAnObject, "is not an %s object (got %s)", format, typeName(val))
+	}
+	m, err := jsObjectToMap(val)
+	if err != nil {
+		return rgbColor{}, err
+	}
+	if format == "rgb" {
+		r, err := colorComponent(m, "r", 255)
+		if err != nil {
+			return rgbColor{}, err
+		}
+		g, err := colorComponent(m, "g", 255)
+		if err != nil {
+			return rgbColor{}, err
+		}
+		b, err := colorComponent(m, "b", 255)
+		if err != nil {
+			return rgbColor{}, err
+		}
+		return rgbColor{r, g, b}, nil
+	}
+	h, err := colorComponent(m, "h", 360)
+	if err != nil {
+		return rgbColor{}, err
+	}
+	s, err := colorComponent(m, "s", 100)
+	if err != nil {
+		return rgbColor{}, err
+	}
+	l, err := colorComponent(m, "l", 100)
+	if err != nil {
+		return rgbColor{}, err
+	}
+	return hslToRGB(h, s/100, l/100), nil
+}
+
+// オブジェクトから 0 以上 max 以下の数値の成分を取り出す
+func colorComponent(m map[string]interface{}, key string, max float64) (float64, error) {
+	v, ok := m[key].(float64)
+	if !ok {
+		return 0, newError(errCodeNotANumber, "property %q is not a number", key)
+	}
+	if math.IsNaN(v) || v < 0 || v > max {
+		return 0, newError(errCodeOutOfRange, "property %q must be between 0 and %v (got %v)", key, max, v)
+	}
+	return v, nil
+}
+
+// "#rrggbb" または "#rgb" 形式の文字列を解析する
+func parseHexColor(s string) (rgbColor, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return rgbColor{}, newError(errCodeInvalidColor, "is not a valid hex color %q: expected #rgb or #rrggbb", s)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rgbColor{}, newError(errCodeInvalidColor, "is not a valid hex color %q: contains non-hex digits", s)
+	}
+	return rgbColor{float64(n >> 16 & 0xff), float64(n >> 8 & 0xff), float64(n & 0xff)}, nil
+}
+
+// rgbColor を format の形式のJavaScriptの値にする
+func colorToJS(c rgbColor, format string) js.Value {
+	r, g, b := math.Round(c.r), math.Round(c.g), math.Round(c.b)
+	switch format {
+	case "hex":
+		return js.ValueOf(fmt.Sprintf("#%02x%02x%02x", int(r), int(g), int(b)))
+	case "rgb":
+		return objectResult(field("r", int(r)), field("g", int(g)), field("b", int(b)))
+	default:
+		h, s, l := rgbToHSL(c)
+		return objectResult(
+			field("h", roundTo(h, 1, roundHalfUp)),
+			field("s", roundTo(s*100, 1, roundHalfUp)),
+			field("l", roundTo(l*100, 1, roundHalfUp)),
+		)
+	}
+}
+
+// RGBを色相 h (0以上360未満の度)、彩度 s と明度 l (0から1) に変換する
+func rgbToHSL(c rgbColor) (h, s, l float64) {
+	r, g, b := c.r/255, c.g/255, c.b/255
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+	d := max - min
+	if d == 0 {
+		// 無彩色では色相と彩度は 0 とする
+		return 0, 0, l
+	}
+	s = d / (1 - math.Abs(2*l-1))
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+// 色相 h (度)、彩度 s と明度 l (0から1) をRGBに変換する
+func hslToRGB(h, s, l float64) rgbColor {
+	h = math.Mod(h, 360)
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - chroma/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return rgbColor{(r + m) * 255, (g + m) * 255, (b + m) * 255}
+}
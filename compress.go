@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"syscall/js"
+)
+
+// gunzip が展開する最大サイズ
+// 小さな入力から巨大なデータに展開される圧縮爆弾でWasmのメモリを使い切らないよう制限する
+const maxGunzipSize = 256 << 20
+
+// JavaScriptから呼び出される gzip 関数
+// Uint8Array をgzip形式で圧縮し、Uint8Array で返す
+// 省略可能な第2引数で圧縮レベル (-2〜9、既定値は -1 = gzip.DefaultCompression) を指定できる
+func gzipBytes(this js.Value, args []js.Value) interface{} {
+	level := gzip.DefaultCompression
+	if levelArg, ok := optionalArg(args, 1); ok {
+		n, err := 安全な整数に変換(levelArg)
+		if err != nil {
+			return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+		}
+		if n < gzip.HuffmanOnly || n > gzip.BestCompression {
+			return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must be between %d and %d (got %d)", gzip.HuffmanOnly, gzip.BestCompression, n))
+		}
+		level = n
+	}
+
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return toJSError(err)
+	}
+	if _, err := w.Write(uint8ArrayToBytes(args[0])); err != nil {
+		return toJSError(err)
+	}
+	if err := w.Close(); err != nil {
+		return toJSError(err)
+	}
+	return bytesToUint8Array(buf.Bytes())
+}
+
+// JavaScriptから呼び出される gunzip 関数
+// gzip形式の Uint8Array を展開し、Uint8Array で返す
+// 壊れた入力は code が "INVALID_GZIP" の Error になる
+func gunzipBytes(this js.Value, args []js.Value) interface{} {
+	r, err := gzip.NewReader(bytes.NewReader(uint8ArrayToBytes(args[0])))
+	if err != nil {
+		return newJSError(errCodeInvalidGzip, fmt.Sprintf("Malformed gzip input: %v", err))
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, maxGunzipSize+1))
+	if err != nil {
+		return newJSError(errCodeInvalidGzip, fmt.Sprintf("Malformed gzip input: %v", err))
+	}
+	if len(data) > maxGunzipSize {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Decompressed data exceeds %d bytes", maxGunzipSize))
+	}
+	return bytesToUint8Array(data)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"syscall/js"
+	"time"
+)
+
+// computeWithTimeout が受け付ける上限値。エラトステネスの篩で上限値と同じ長さのスライスを確保するため制限する
+const maxComputeLimit = 50_000_000
+
+// 何回のループごとに ctx を確認し、他のgoroutineに実行を譲るか
+const cancelCheckInterval = 1 << 14
+
+// JavaScriptから呼び出される computeWithTimeout 関数
+// limit 以下の素数の個数を数える重い計算を goroutine 上で実行し、結果を Promise で返す
+//
+//	goWasm.computeWithTimeout(10_000_000, 500) // 500ミリ秒以内に終わらなければ reject
+//
+// 計算は context.WithTimeout で作った ctx を定期的に確認し、期限を過ぎたらその場で打ち切って goroutine を終了する。
+// js/wasm はシングルスレッドのため、計算中は runtime.Gosched で定期的に実行を譲らないとタイマーが発火できない。
+// なお計算中はJavaScriptのイベントループも止まるため、UIを止めたくない場合は Web Worker 上で実行すること。
+func computeWithTimeout(this js.Value, args []js.Value) interface{} {
+	return newPromise(func(resolve, reject js.Value) {
+		limit, err := 安全な整数に変換(args[0])
+		if err != nil {
+			rejectWithError(reject, errCodeOf(err), "Argument 1 "+errMessageOf(err))
+			return
+		}
+		if limit < 0 || limit > maxComputeLimit {
+			rejectWithError(reject, errCodeOutOfRange, fmt.Sprintf("Argument 1 must be between 0 and %d (got %d)", maxComputeLimit, limit))
+			return
+		}
+		timeoutMs, err := 安全な整数に変換(args[1])
+		if err != nil {
+			rejectWithError(reject, errCodeOf(err), "Argument 2 "+errMessageOf(err))
+			return
+		}
+		if timeoutMs <= 0 {
+			rejectWithError(reject, errCodeOutOfRange, fmt.Sprintf("Argument 2 must be positive (got %d)", timeoutMs))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMs)*time.Millisecond)
+		defer cancel()
+		count, err := countPrimes(ctx, limit)
+		if err != nil {
+			logf(levelDebug, "computeWithTimeout cancelled after %dms", timeoutMs)
+			rejectWithError(reject, errCodeTimeout, fmt.Sprintf("Computation timed out after %dms", timeoutMs))
+			return
+		}
+		resolve.Invoke(count)
+	})
+}
+
+// limit 以下の素数の個数をエラトステネスの篩で数える
+// ctx がキャンセルされた場合は計算を中断して ctx.Err() を返す
+func countPrimes(ctx context.Context, limit int) (int, error) {
+	if limit < 2 {
+		return 0, nil
+	}
+	composite := make([]bool, limit+1)
+	count := 0
+	for i := 2; i <= limit; i++ {
+		if i%cancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			runtime.Gosched()
+		}
+		if composite[i] {
+			continue
+		}
+		count++
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+	}
+	return count, nil
+}
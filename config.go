@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"syscall/js"
+)
+
+// configure で実行時に切り替えられるモジュール全体の設定
+type moduleConfig struct {
+	// true の場合、add などの整数演算は小数部を持つ値や安全な整数の範囲を超える値を切り捨てずにエラーにする
+	strictTypes bool
+	// 丸めモードを指定せずに round や丸めオプションを使ったときのモード
+	roundingMode roundingMode
+}
+
+var (
+	// config は複数のgoroutineから参照されるため configMu で保護する
+	configMu sync.Mutex
+	config   = moduleConfig{roundingMode: defaultRoundingMode}
+)
+
+// 現在の設定のコピーを返す
+func currentConfig() moduleConfig {
+	configMu.Lock()
+	defer configMu.Unlock()
+	return config
+}
+
+// JavaScriptから呼び出される configure 関数
+// オブジェクトで渡した設定を反映し、反映後の設定を返す。渡さなかった項目は変更しない
+//
+//	goWasm.configure({ strictTypes: true, logLevel: "debug", roundingMode: "banker" })
+//	// => { strictTypes: true, logLevel: "debug", roundingMode: "halfEven", unknownKeys: [] }
+//
+// 設定できる項目は次のとおり
+//
+//	strictTypes  (boolean) 整数演算で切り捨てが起きる入力をエラーにする。既定値は false
+//	logLevel     (string)  これより低いレベルのログを捨てる ("debug" "info" "warn" "error")。setLogHandler の第2引数と同じ
+//	roundingMode (string)  既定の丸めモード ("halfUp" "halfEven"、"banker" は "halfEven" と同じ)。既定値は "halfUp"
+//
+// 不明なキーは無視して警告のログを出し、結果の unknownKeys に含める。
+// 既知のキーの値が不正な場合は何も変更せずに Error を返す。
+func configure(this js.Value, args []js.Value) interface{} {
+	opts := args[0]
+	next := currentConfig()
+	var (
+		newLogLevel logLevel
+		setLogLevel bool
+		unknownKeys = []string{}
+	)
+
+	keys := js.Global().Get("Object").Call("keys", opts)
+	for i := 0; i < keys.Length(); i++ {
+		key := keys.Index(i).String()
+		val := opts.Get(key)
+		switch key {
+		case "strictTypes":
+			if !hasType(val, js.TypeBoolean) {
+				return newJSError(errCodeWrongType, fmt.Sprintf("Option %q is not a boolean (got %s)", key, typeName(val)))
+			}
+			next.strictTypes = val.Bool()
+		case "logLevel":
+			if !hasType(val, js.TypeString) {
+				return newJSError(errCodeNotAString, fmt.Sprintf("Option %q is not a string (got %s)", key, typeName(val)))
+			}
+			level, err := parseLogLevel(val.String())
+			if err != nil {
+				return toJSError(err)
+			}
+			newLogLevel, setLogLevel = level, true
+		case "roundingMode":
+			if !hasType(val, js.TypeString) {
+				return newJSError(errCodeNotAString, fmt.Sprintf("Option %q is not a string (got %s)", key, typeName(val)))
+			}
+			mode, err := parseRoundingMode(val.String())
+			if err != nil {
+				return toJSError(err)
+			}
+			next.roundingMode = mode
+		default:
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+
+	configMu.Lock()
+	config = next
+	configMu.Unlock()
+	logMu.Lock()
+	if setLogLevel {
+		minLogLevel = newLogLevel
+	}
+	current := minLogLevel
+	logMu.Unlock()
+
+	sort.Strings(unknownKeys)
+	if len(unknownKeys) > 0 {
+		logf(levelWarn, "configure ignored unknown options: %v", unknownKeys)
+	}
+	return objectResult(
+		field("strictTypes", next.strictTypes),
+		field("logLevel", current.String()),
+		field("roundingMode", string(next.roundingMode)),
+		field("unknownKeys", unknownKeys),
+	)
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"unicode"
+)
+
+// jsToGo が辿るネストの上限。循環参照を持つオブジェクトで無限再帰しないようにする
+const maxConvertDepth = 100
+
+// JavaScriptのオブジェクトをGoの map[string]interface{} に変換するヘルパー関数
+// 安全にIntに変換 のスカラー版に対する、構造化データ版にあたる
+//
+// 各プロパティは Object.keys で列挙した自身の列挙可能なキーのみを対象とし、値は jsToGo の規則で再帰的に変換する。
+// 入力が配列を含むオブジェクト型でない場合 (null / undefined を含む) はエラーを返す。
+func jsObjectToMap(val js.Value) (map[string]interface{}, error) {
+	if !hasType(val, js.TypeObject) || isJSArray(val) {
+		return nil, newError(errCodeNotAnObject, "is not an object (got %s)", typeName(val))
+	}
+	m, err := jsObjectToMapDepth(val, 0)
+	return m, formatPathError(err)
+}
+
+func jsObjectToMapDepth(val js.Value, depth int) (map[string]interface{}, error) {
+	keys := js.Global().Get("Object").Call("keys", val)
+	m := make(map[string]interface{}, keys.Length())
+	for i := 0; i < keys.Length(); i++ {
+		key := keys.Index(i).String()
+		v, err := jsToGoDepth(val.Get(key), depth+1)
+		if err != nil {
+			return nil, withPathSegment(err, propertySegment(key))
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+// js.Value をGoの値に再帰的に変換する
+//
+//	undefined / null -> nil
+//	boolean          -> bool
+//	number           -> float64
+//	string           -> string
+//	BigInt           -> *big.Int
+//	Array            -> []interface{}
+//	その他の object  -> map[string]interface{}
+//
+// function / symbol など表現できない型はエラーを返す
+// ネストした要素で失敗した場合、メッセージの先頭に "at a.b[3]: " の形でその位置を示す
+func jsToGo(val js.Value) (interface{}, error) {
+	v, err := jsToGoDepth(val, 0)
+	return v, formatPathError(err)
+}
+
+func jsToGoDepth(val js.Value, depth int) (interface{}, error) {
+	if depth > maxConvertDepth {
+		return nil, newError(errCodeTooDeep, "nesting exceeds %d levels (circular reference?)", maxConvertDepth)
+	}
+	// BigInt は Type() を呼ぶと panic するため、switch より前に判定する
+	if isBigInt(val) {
+		return 安全にBigIntに変換(val)
+	}
+	switch val.Type() {
+	case js.TypeUndefined, js.TypeNull:
+		return nil, nil
+	case js.TypeBoolean:
+		return val.Bool(), nil
+	case js.TypeNumber:
+		return val.Float(), nil
+	case js.TypeString:
+		return val.String(), nil
+	case js.TypeObject:
+		if isJSArray(val) {
+			list := make([]interface{}, val.Length())
+			for i := range list {
+				v, err := jsToGoDepth(val.Index(i), depth+1)
+				if err != nil {
+					return nil, withPathSegment(err, "["+strconv.Itoa(i)+"]")
+				}
+				list[i] = v
+			}
+			return list, nil
+		}
+		return jsObjectToMapDepth(val, depth)
+	default:
+		return nil, newError(errCodeWrongType, "unsupported type %s", val.Type())
+	}
+}
+
+// 変換中のエラーと、それが起きた要素までのパス
+// 再帰の戻りで各階層がパスの1区間を積み、メッセージは最上位の formatPathError で1度だけ組み立てる
+type convertPathError struct {
+	err      error
+	segments []string // 内側の要素から順に並ぶ
+}
+
+func (e *convertPathError) Error() string { return e.err.Error() }
+func (e *convertPathError) Unwrap() error { return e.err }
+
+// err にパスの1区間を追加する
+// TOO_DEEP は循環参照だとパスが同じキーの上限回の繰り返しになるため、位置を付けずにそのまま返す
+func withPathSegment(err error, segment string) error {
+	if errCodeOf(err) == errCodeTooDeep {
+		return err
+	}
+	if pe, ok := err.(*convertPathError); ok {
+		pe.segments = append(pe.segments, segment)
+		return pe
+	}
+	return &convertPathError{err: err, segments: []string{segment}}
+}
+
+// 積まれたパスを "a.b[3]" の形にまとめ、メッセージの先頭に付けたエラーにする
+func formatPathError(err error) error {
+	pe, ok := err.(*convertPathError)
+	if !ok {
+		return err
+	}
+	var b strings.Builder
+	for i := len(pe.segments) - 1; i >= 0; i-- {
+		b.WriteString(pe.segments[i])
+	}
+	path := strings.TrimPrefix(b.String(), ".")
+	return newError(errCodeOf(pe.err), "at %s: %s", path, errMessageOf(pe.err))
+}
+
+// プロパティ名をパスの区間にする。識別子として書けないキーは ["a b"] の形で引用する
+func propertySegment(key string) string {
+	if key == "" {
+		return "[" + strconv.Quote(key) + "]"
+	}
+	for i, r := range key {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return "[" + strconv.Quote(key) + "]"
+		}
+	}
+	return "." + key
+}
+
+// obj に自身のプロパティ key を定義するJavaScriptの関数。呼び出しのたびに作らないよう一度だけ作成する
+var defineOwnProperty = js.Global().Get("Function").New("obj", "key", "value",
+	"Object.defineProperty(obj, key, { value: value, writable: true, enumerable: true, configurable: true });")
+
+// obj にキー key のプロパティを値 value で定義する
+// obj.Set は代入になるため、キーが "__proto__" だとプロパティが作られずにプロトタイプが置き換わる。
+// 外部から来たキー (jsObjectToMap で読み取ったキーやURLのクエリのキーなど) を書き込む場合はこちらを使う
+func setOwnProperty(obj js.Value, key string, value js.Value) {
+	defineOwnProperty.Invoke(obj, key, value)
+}
+
+// Goの map[string]interface{} をJavaScriptのオブジェクトに変換するヘルパー関数
+// jsObjectToMap の逆変換にあたり、値は goToJS の規則で再帰的に変換する
+func mapToJSObject(m map[string]interface{}) js.Value {
+	obj := js.Global().Get("Object").New()
+	for k, v := range m {
+		setOwnProperty(obj, k, goToJS(v))
+	}
+	return obj
+}
+
+// Goの値をJavaScriptの値に再帰的に変換する
+//
+//	nil                         -> null
+//	js.Value                    -> そのまま
+//	bool / 数値型 / string      -> js.ValueOf と同じ
+//	*big.Int                    -> BigInt
+//	[]byte                      -> Uint8Array
+//	スライス / 配列             -> Array (要素も再帰的に変換)
+//	map[string]T                -> object (値も再帰的に変換)
+//
+// js.ValueOf はそれ以外の型を渡すとpanicするため、
+// 変換できない型 (構造体、チャネル、関数、文字列以外をキーに持つmapなど) は undefined を返す
+func goToJS(v interface{}) js.Value {
+	switch x := v.(type) {
+	case nil:
+		return js.Null()
+	case js.Value:
+		return x
+	case bool, string,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64:
+		return js.ValueOf(x)
+	case *big.Int:
+		return bigIntToJS(x)
+	case []byte:
+		return bytesToUint8Array(x)
+	case map[string]interface{}:
+		return mapToJSObject(x)
+	case []interface{}:
+		arr := js.Global().Get("Array").New(len(x))
+		for i, e := range x {
+			arr.SetIndex(i, goToJS(e))
+		}
+		return arr
+	}
+
+	// 名前付きの型 (type Celsius float64 など) や要素型が固定のスライス/mapは reflect で辿る
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return js.ValueOf(rv.Bool())
+	case reflect.String:
+		return js.ValueOf(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return js.ValueOf(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return js.ValueOf(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return js.ValueOf(rv.Float())
+	case reflect.Slice, reflect.Array:
+		arr := js.Global().Get("Array").New(rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			arr.SetIndex(i, goToJS(rv.Index(i).Interface()))
+		}
+		return arr
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return js.Undefined()
+		}
+		obj := js.Global().Get("Object").New()
+		iter := rv.MapRange()
+		for iter.Next() {
+			setOwnProperty(obj, iter.Key().String(), goToJS(iter.Value().Interface()))
+		}
+		return obj
+	default:
+		return js.Undefined()
+	}
+}
+
+// 名前付きの戻り値の1項目
+type resultField struct {
+	name  string
+	value interface{}
+}
+
+// 名前付きの戻り値を作る
+func field(name string, value interface{}) resultField {
+	return resultField{name: name, value: value}
+}
+
+// 複数の値を名前付きのプロパティを持つJavaScriptのオブジェクトとして返す
+// GoからJavaScriptへは多値を返せないため、複数の結果を返す関数はこの形に揃える
+//
+//	return objectResult(field("quotient", q), field("remainder", r)) // => { quotient, remainder }
+//
+// map を使う mapToJSObject と異なり、プロパティは引数の順に定義されるため console.log などでの表示順が安定する
+// decodeQuery のように名前が外部の入力から来ることもあるため、プロパティは setOwnProperty で定義する
+func objectResult(fields ...resultField) js.Value {
+	obj := js.Global().Get("Object").New()
+	for _, f := range fields {
+		setOwnProperty(obj, f.name, goToJS(f.value))
+	}
+	return obj
+}
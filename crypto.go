@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される sha256 関数
+// 文字列または Uint8Array を受け取り、SHA-256ダイジェストを16進文字列で返す
+// 空の入力は空文字列のダイジェスト (e3b0c442...b855) になる
+func sha256Hex(this js.Value, args []js.Value) interface{} {
+	data, err := bytesFromJS(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	digest := sha256.Sum256(data)
+	return js.ValueOf(hex.EncodeToString(digest[:]))
+}
+
+// HMAC-SHA256 の引数 (message, secret) をバイト列として取り出して署名を計算する
+func hmacSHA256(args []js.Value) ([]byte, error) {
+	message, err := bytesFromJS(args[0])
+	if err != nil {
+		return nil, newError(errCodeOf(err), "Argument 1 %s", errMessageOf(err))
+	}
+	secret, err := bytesFromJS(args[1])
+	if err != nil {
+		return nil, newError(errCodeOf(err), "Argument 2 %s", errMessageOf(err))
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(message)
+	return mac.Sum(nil), nil
+}
+
+// JavaScriptから呼び出される hmac 関数
+// message と secret (それぞれ文字列または Uint8Array) から HMAC-SHA256 の署名を計算し、16進文字列で返す
+func hmacHex(this js.Value, args []js.Value) interface{} {
+	sig, err := hmacSHA256(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	return js.ValueOf(hex.EncodeToString(sig))
+}
+
+// JavaScriptから呼び出される verifyHMAC 関数
+// signature (16進文字列) が message と secret の HMAC-SHA256 の署名と一致するかを返す
+//
+// 比較には hmac.Equal を使い、一致する桁数によって処理時間が変わらないようにする。
+// 文字列の === で比較すると、応答時間の差から正しい署名を1桁ずつ推測されるおそれがある。
+// 16進として解釈できない署名は一致しないものとして false を返す。
+func verifyHMAC(this js.Value, args []js.Value) interface{} {
+	expected, err := hmacSHA256(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	sig, err := hex.DecodeString(args[2].String())
+	if err != nil {
+		return js.ValueOf(false)
+	}
+	return js.ValueOf(hmac.Equal(sig, expected))
+}
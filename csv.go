@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall/js"
+	"unicode/utf8"
+)
+
+// JavaScriptから呼び出される parseCSV 関数
+// CSVテキストを解析し、行の配列 (各行はフィールド文字列の配列) を返す
+// ダブルクォートで囲まれたフィールド内の区切り文字・改行・"" (エスケープされた引用符) は encoding/csv の規則 (RFC 4180) で扱う
+// 省略可能な第2引数で区切り文字 (1文字) を指定できる。既定値は ","
+//
+// 行ごとのフィールド数は揃っていなくてもよい。
+// 不正なCSVの場合は code が "INVALID_CSV" の Error を返し、line / column プロパティに問題の位置 (1始まり) を設定する
+func parseCSV(this js.Value, args []js.Value) interface{} {
+	r := csv.NewReader(strings.NewReader(args[0].String()))
+	r.FieldsPerRecord = -1
+	if delimArg, ok := optionalArg(args, 1); ok {
+		delim := delimArg.String()
+		comma, size := utf8.DecodeRuneInString(delim)
+		if size == 0 || size != len(delim) || comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError {
+			return newJSError(errCodeInvalidOption, fmt.Sprintf("Invalid delimiter %q: must be a single character other than a quote or newline", delim))
+		}
+		r.Comma = comma
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		errVal := newJSError(errCodeInvalidCSV, fmt.Sprintf("Malformed CSV: %v", err))
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			errVal.Set("line", parseErr.Line)
+			errVal.Set("column", parseErr.Column)
+		}
+		return errVal
+	}
+	return goToJS(records)
+}
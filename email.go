@@ -0,0 +1,32 @@
+package main
+
+import (
+	"net/mail"
+	"strings"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される validateEmail 関数
+// メールアドレスを RFC 5322 に従って解析し、{ valid, normalized, reason } を返す
+//
+//	goWasm.validateEmail("Alice <Alice@Example.COM>")
+//	// => { valid: true, normalized: "Alice@example.com", reason: "" }
+//
+// 解析には net/mail.ParseAddress を使うため、表示名付きの形式や "john doe"@example.com のような引用符付きのローカル部も受け付ける。
+// normalized は表示名を除いたアドレスで、ドメインだけを小文字にする (ローカル部の大文字小文字は区別されうるため変更しない)。
+// 無効な場合は valid が false、normalized が空文字列になり、reason に理由が入る。形式の誤りは Error ではなく結果として返す。
+func validateEmail(this js.Value, args []js.Value) interface{} {
+	addr, err := mail.ParseAddress(args[0].String())
+	if err != nil {
+		return objectResult(field("valid", false), field("normalized", ""), field("reason", err.Error()))
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	normalized := addr.Address[:at+1] + strings.ToLower(addr.Address[at+1:])
+	// 引用符が必要なローカル部は mail.Address の String で引用し直し、表示名のない場合に付く <> を外す
+	quoted := (&mail.Address{Address: normalized}).String()
+	return objectResult(
+		field("valid", true),
+		field("normalized", strings.TrimSuffix(strings.TrimPrefix(quoted, "<"), ">")),
+		field("reason", ""),
+	)
+}
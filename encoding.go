@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"syscall/js"
+)
+
+// base64のアルファベットを省略可能な引数から選択する
+// "std" (既定値) は標準のアルファベット、"url" はURLセーフなアルファベット (RFC 4648 §5) を使う
+func base64Encoding(args []js.Value, index int) (*base64.Encoding, error) {
+	alphabet, ok := optionalArg(args, index)
+	if !ok {
+		return base64.StdEncoding, nil
+	}
+	switch alphabet.String() {
+	case "std":
+		return base64.StdEncoding, nil
+	case "url":
+		return base64.URLEncoding, nil
+	default:
+		return nil, newError(errCodeInvalidOption, "Unknown base64 alphabet %q: expected \"std\" or \"url\"", alphabet.String())
+	}
+}
+
+// JavaScriptから呼び出される base64Encode 関数
+// Uint8Array を受け取り、base64文字列を返す
+func base64Encode(this js.Value, args []js.Value) interface{} {
+	enc, err := base64Encoding(args, 1)
+	if err != nil {
+		return toJSError(err)
+	}
+	return js.ValueOf(enc.EncodeToString(uint8ArrayToBytes(args[0])))
+}
+
+// JavaScriptから呼び出される base64Decode 関数
+// base64文字列を受け取り、デコードしたバイト列を Uint8Array で返す
+func base64Decode(this js.Value, args []js.Value) interface{} {
+	enc, err := base64Encoding(args, 1)
+	if err != nil {
+		return toJSError(err)
+	}
+	data, err := enc.DecodeString(args[0].String())
+	if err != nil {
+		return newJSError(errCodeInvalidBase64, fmt.Sprintf("Malformed base64 input: %v", err))
+	}
+	return bytesToUint8Array(data)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される deepEqual 関数
+// 2つの値を jsToGo でGoの値に変換した上で構造的に比較し、真偽値を返す
+//
+// 比較の規則は次のとおり
+//   - 数値は NaN 同士を等しいとみなし、+0 と -0 も等しいとみなす (JavaScriptの SameValueZero と同じ)
+//   - 型が異なる値は等しくない。1 と "1"、1 と 1n、配列と配列に似たオブジェクトはいずれも false
+//   - null と undefined は jsToGo でどちらも nil になるため等しいとみなす
+//   - 配列は長さと各要素が、オブジェクトはキーの集合と各プロパティの値が等しい場合に等しい (キーの順序は問わない)
+//
+// 関数など変換できない値や循環参照を含む場合は Error を返す。
+func deepEqual(this js.Value, args []js.Value) interface{} {
+	a, err := jsToGo(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	b, err := jsToGo(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	return js.ValueOf(goValuesEqual(a, b))
+}
+
+// jsToGo が返す値同士を deepEqual の規則で比較する
+func goValuesEqual(a, b interface{}) bool {
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		if math.IsNaN(x) && math.IsNaN(y) {
+			return true
+		}
+		return x == y
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case *big.Int:
+		y, ok := b.(*big.Int)
+		return ok && x.Cmp(y) == 0
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !goValuesEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, xv := range x {
+			yv, ok := y[k]
+			if !ok || !goValuesEqual(xv, yv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
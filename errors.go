@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"syscall/js"
+)
+
+// JavaScript側で機械的に判別するためのエラーコード
+const (
+	errCodeInvalidArgCount   = "INVALID_ARG_COUNT"
+	errCodeNotANumber        = "NOT_A_NUMBER"
+	errCodeNotAnInteger      = "NOT_AN_INTEGER"
+	errCodeNotFinite         = "NOT_FINITE"
+	errCodeOverflow          = "OVERFLOW"
+	errCodeOutOfRange        = "OUT_OF_RANGE"
+	errCodeNotAnArray        = "NOT_AN_ARRAY"
+	errCodeEmptyArray        = "EMPTY_ARRAY"
+	errCodeDimensionMismatch = "DIMENSION_MISMATCH"
+	errCodeNotAString        = "NOT_A_STRING"
+	errCodeNotABigInt        = "NOT_A_BIGINT"
+	errCodeNotAnObject       = "NOT_AN_OBJECT"
+	errCodeTooDeep           = "TOO_DEEP"
+	errCodeWrongType         = "WRONG_TYPE"
+	errCodeNullArg           = "NULL_ARG"
+	errCodeInvalidOption     = "INVALID_OPTION"
+	errCodeInvalidBase64     = "INVALID_BASE64"
+	errCodeInvalidPattern    = "INVALID_PATTERN"
+	errCodeInvalidLayout     = "INVALID_LAYOUT"
+	errCodeInvalidTimezone   = "INVALID_TIMEZONE"
+	errCodeInvalidGzip       = "INVALID_GZIP"
+	errCodeInvalidCSV        = "INVALID_CSV"
+	errCodeInvalidJSON       = "INVALID_JSON"
+	errCodeInvalidQuery      = "INVALID_QUERY"
+	errCodeInvalidColor      = "INVALID_COLOR"
+	errCodeInvalidTemplate   = "INVALID_TEMPLATE"
+	errCodeMissingKey        = "MISSING_KEY"
+	errCodeInvalidNumber     = "INVALID_NUMBER"
+	errCodeRandomFailure     = "RANDOM_FAILURE"
+	errCodeUnknownOp         = "UNKNOWN_OP"
+	errCodeDivisionByZero    = "DIVISION_BY_ZERO"
+	errCodeNetworkError      = "NETWORK_ERROR"
+	errCodeHTTPError         = "HTTP_ERROR"
+	errCodeTimeout           = "TIMEOUT"
+	errCodeRateLimited       = "RATE_LIMITED"
+	errCodeRetryExhausted    = "RETRY_EXHAUSTED"
+	errCodePanic             = "PANIC"
+	errCodeInternal          = "INTERNAL_ERROR"
+)
+
+// コード付きのGo側エラー
+// ヘルパー関数は通常のerrorとしてこれを返し、JavaScriptへ返す直前に toJSError で変換する
+type wasmError struct {
+	code    string
+	message string
+}
+
+func (e *wasmError) Error() string {
+	return e.code + ": " + e.message
+}
+
+// コードとフォーマット済みメッセージから wasmError を作成する
+func newError(code, format string, a ...interface{}) error {
+	return &wasmError{code: code, message: fmt.Sprintf(format, a...)}
+}
+
+// エラーのコードを取り出す (wasmError 以外は INTERNAL_ERROR)
+func errCodeOf(err error) string {
+	var we *wasmError
+	if errors.As(err, &we) {
+		return we.code
+	}
+	return errCodeInternal
+}
+
+// エラーのメッセージ部分のみを取り出す (コードを含まない)
+func errMessageOf(err error) string {
+	var we *wasmError
+	if errors.As(err, &we) {
+		return we.message
+	}
+	return err.Error()
+}
+
+// JavaScriptの Error オブジェクトを作成し、code プロパティを付与する
+// 呼び出し側では `result instanceof Error` や `result.code` で判別できる
+func newJSError(code, msg string) js.Value {
+	errVal := js.Global().Get("Error").New(msg)
+	errVal.Set("code", code)
+	return errVal
+}
+
+// Goのerrorを JavaScript の Error オブジェクトに変換する
+// wasmError 以外のエラーは INTERNAL_ERROR として扱う
+func toJSError(err error) js.Value {
+	return newJSError(errCodeOf(err), errMessageOf(err))
+}
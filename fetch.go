@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"syscall/js"
+	"time"
+)
+
+// JavaScriptから呼び出される fetch 関数
+// JavaScriptの fetch で URL を GET し、レスポンス本文の文字列で resolve する Promise を返す
+// 省略可能な第2引数でタイムアウトをミリ秒で指定できる
+//
+// Go の net/http は使わず、globalThis.fetch を直接呼び出して goroutine 上で結果を待つ。
+// js/wasm の net/http は Node.js 上では fetch を使わず (process.argv0 が node の場合は無効になる)、
+// 実際には通信できないダイヤラーにフォールバックするため、Next.js のAPIルートから使えない。
+// ブラウザで実行する場合は CORS の制約をそのまま受け、Access-Control-Allow-Origin を返さない
+// 別オリジンへのリクエストは、ステータスコードを得る前に NETWORK_ERROR として失敗する。
+//
+// ステータスコードが2xx以外の場合は code が "HTTP_ERROR" で status プロパティを持つ Error で reject する
+func fetchURL(this js.Value, args []js.Value) interface{} {
+	return newPromise(func(resolve, reject js.Value) {
+		rawURL := args[0].String()
+		if _, err := url.Parse(rawURL); err != nil {
+			rejectWithError(reject, errCodeInvalidOption, fmt.Sprintf("Invalid URL %q: %v", rawURL, err))
+			return
+		}
+		fetch := js.Global().Get("fetch")
+		if !hasType(fetch, js.TypeFunction) {
+			rejectWithError(reject, errCodeNetworkError, "Request failed: fetch is not available in this environment")
+			return
+		}
+
+		// タイムアウトは AbortController で通信と本文の読み込みをまとめて打ち切る
+		controller := js.Global().Get("AbortController").New()
+		var timedOut atomic.Bool
+		timeoutMs := 0
+		if timeoutArg, ok := optionalArg(args, 1); ok {
+			ms, err := 安全な整数に変換(timeoutArg)
+			if err != nil {
+				rejectWithError(reject, errCodeOf(err), "Argument 2 "+errMessageOf(err))
+				return
+			}
+			if ms <= 0 {
+				rejectWithError(reject, errCodeOutOfRange, fmt.Sprintf("Argument 2 must be positive (got %d)", ms))
+				return
+			}
+			timeoutMs = ms
+			timer := time.AfterFunc(time.Duration(ms)*time.Millisecond, func() {
+				timedOut.Store(true)
+				controller.Call("abort")
+			})
+			defer timer.Stop()
+		}
+
+		opts := js.Global().Get("Object").New()
+		opts.Set("signal", controller.Get("signal"))
+		resp, reason, ok := awaitPromise(fetch.Invoke(rawURL, opts))
+		if !ok {
+			rejectFetchError(reject, reason, timedOut.Load(), timeoutMs)
+			return
+		}
+		body, reason, ok := awaitPromise(resp.Call("text"))
+		if !ok {
+			rejectFetchError(reject, reason, timedOut.Load(), timeoutMs)
+			return
+		}
+		if status := resp.Get("status").Int(); status < 200 || status > 299 {
+			errVal := newJSError(errCodeHTTPError, fmt.Sprintf("Request to %s failed with status %d", rawURL, status))
+			errVal.Set("status", status)
+			reject.Invoke(errVal)
+			return
+		}
+		resolve.Invoke(body)
+	})
+}
+
+// fetch の失敗で reject する。タイムアウトとそれ以外のネットワークエラーはコードで区別する
+// Node.js の fetch は "fetch failed" とだけ報告し詳細を cause に持つため、cause があればメッセージに含める
+func rejectFetchError(reject js.Value, reason js.Value, timedOut bool, timeoutMs int) {
+	if timedOut {
+		rejectWithError(reject, errCodeTimeout, fmt.Sprintf("Request timed out after %dms", timeoutMs))
+		return
+	}
+	msg := reasonMessage(reason)
+	if hasType(reason, js.TypeObject) {
+		if cause := reason.Get("cause"); !cause.IsUndefined() && !cause.IsNull() {
+			msg += ": " + reasonMessage(cause)
+		}
+	}
+	rejectWithError(reject, errCodeNetworkError, "Request failed: "+msg)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"syscall/js"
+)
+
+// processJSON が受け取る注文データ
+type order struct {
+	Items   []orderItem `json:"items"`
+	TaxRate float64     `json:"taxRate"`
+}
+
+// 注文の明細1行分
+type orderItem struct {
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+}
+
+// JavaScriptから呼び出される processJSON 関数
+// 注文データのJSON文字列を受け取ってGoの構造体に変換し、集計結果をJavaScriptのオブジェクトで返す
+//
+//	goWasm.processJSON('{"items":[{"name":"a","price":100,"quantity":2}],"taxRate":0.1}')
+//	// => { itemCount: 2, subtotal: 200, tax: 20, total: 220 }
+//
+// JSONが不正な場合は code が "INVALID_JSON" の Error を返し、
+// offset プロパティに入力文字列中の問題が見つかったバイト位置を設定する
+func processJSON(this js.Value, args []js.Value) interface{} {
+	var o order
+	if err := json.Unmarshal([]byte(args[0].String()), &o); err != nil {
+		return jsonError(err)
+	}
+
+	itemCount := 0
+	subtotal := 0.0
+	for _, item := range o.Items {
+		itemCount += item.Quantity
+		subtotal += item.Price * float64(item.Quantity)
+	}
+	tax := subtotal * o.TaxRate
+	return objectResult(
+		field("itemCount", itemCount),
+		field("subtotal", subtotal),
+		field("tax", tax),
+		field("total", subtotal+tax),
+	)
+}
+
+// json.Unmarshal のエラーを、解析位置 (offset) 付きの JavaScript の Error に変換する
+func jsonError(err error) js.Value {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var offset int64 = -1
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+	errVal := newJSError(errCodeInvalidJSON, fmt.Sprintf("Malformed JSON: %v", err))
+	if offset >= 0 {
+		errVal.Set("offset", offset)
+	}
+	return errVal
+}
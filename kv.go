@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"syscall/js"
+)
+
+// Wasmインスタンス内で呼び出しをまたいで値を保持するキーバリューストア
+// 値はJavaScriptの値そのものではなく jsToGo で変換したGoの値として保存するため、
+// 保存後に元のオブジェクトを書き換えてもストアの内容は変わらない
+var (
+	kvMu    sync.Mutex
+	kvStore = make(map[string]interface{})
+)
+
+// JavaScriptから呼び出される kvSet 関数
+// key に value を保存する。value はJSONで表現できる値 (数値、文字列、真偽値、null、配列、オブジェクト) に限る
+func kvSet(this js.Value, args []js.Value) interface{} {
+	key := args[0].String()
+	value, err := jsToGo(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	kvMu.Lock()
+	kvStore[key] = value
+	kvMu.Unlock()
+	return nil
+}
+
+// JavaScriptから呼び出される kvGet 関数
+// key に保存された値を返す。存在しない場合は undefined を返す
+func kvGet(this js.Value, args []js.Value) interface{} {
+	key := args[0].String()
+	kvMu.Lock()
+	value, ok := kvStore[key]
+	kvMu.Unlock()
+	if !ok {
+		return js.Undefined()
+	}
+	return goToJS(value)
+}
+
+// JavaScriptから呼び出される kvDelete 関数
+// key を削除し、削除前に存在していたかどうかを返す
+func kvDelete(this js.Value, args []js.Value) interface{} {
+	key := args[0].String()
+	kvMu.Lock()
+	_, ok := kvStore[key]
+	delete(kvStore, key)
+	kvMu.Unlock()
+	return js.ValueOf(ok)
+}
+
+// JavaScriptから呼び出される kvKeys 関数
+// 保存されているキーを辞書順に並べた配列を返す
+func kvKeys(this js.Value, args []js.Value) interface{} {
+	kvMu.Lock()
+	keys := make([]string, 0, len(kvStore))
+	for k := range kvStore {
+		keys = append(keys, k)
+	}
+	kvMu.Unlock()
+	sort.Strings(keys)
+	return goToJS(keys)
+}
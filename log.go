@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"syscall/js"
+)
+
+// ログの重要度
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+func (l logLevel) String() string {
+	switch l {
+	case levelDebug:
+		return "debug"
+	case levelInfo:
+		return "info"
+	case levelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// 文字列からログレベルを取得する
+func parseLogLevel(s string) (logLevel, error) {
+	switch s {
+	case "debug":
+		return levelDebug, nil
+	case "info":
+		return levelInfo, nil
+	case "warn":
+		return levelWarn, nil
+	case "error":
+		return levelError, nil
+	default:
+		return 0, newError(errCodeInvalidOption, "Unknown log level %q: expected \"debug\", \"info\", \"warn\" or \"error\"", s)
+	}
+}
+
+var (
+	// logHandler と minLogLevel は複数のgoroutineから参照されるため logMu で保護する
+	logMu sync.Mutex
+	// setLogHandler で設定されたJavaScriptの関数。未設定の場合は undefined
+	logHandler  = js.Undefined()
+	minLogLevel = levelDebug
+)
+
+// ログを出力する
+// ハンドラが設定されていれば handler(level, message) を呼び出し、未設定なら console.log に出力する
+func logf(level logLevel, format string, a ...interface{}) {
+	logMu.Lock()
+	handler, min := logHandler, minLogLevel
+	logMu.Unlock()
+	if level < min {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+
+	// ハンドラ内からGoの関数が呼ばれてもデッドロックしないよう、ロックを外してから呼び出す
+	if hasType(handler, js.TypeFunction) && invokeLogHandler(handler, level, msg) {
+		return
+	}
+	js.Global().Get("console").Call("log", fmt.Sprintf("[go-wasm] [%s] %s", level, msg))
+}
+
+// ログハンドラを呼び出す。ハンドラが例外を投げた場合は false を返す
+func invokeLogHandler(handler js.Value, level logLevel, msg string) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+	handler.Invoke(level.String(), msg)
+	return true
+}
+
+// JavaScriptから呼び出される setLogHandler 関数
+// Go側のログを受け取る関数 (level, message) => void を設定する
+// null または undefined を渡すと既定の console.log への出力に戻す
+// 省略可能な第2引数で、これより低いレベルのログを捨てる最小レベルを指定できる
+// 省略した場合は現在の最小レベル (configure の logLevel で設定したものを含む) をそのまま使う
+func setLogHandler(this js.Value, args []js.Value) interface{} {
+	handler := args[0]
+	if handler.IsNull() {
+		handler = js.Undefined()
+	}
+
+	var (
+		min    logLevel
+		setMin bool
+	)
+	if levelArg, ok := optionalArg(args, 1); ok {
+		level, err := parseLogLevel(levelArg.String())
+		if err != nil {
+			return toJSError(err)
+		}
+		min, setMin = level, true
+	}
+
+	logMu.Lock()
+	logHandler = handler
+	if setMin {
+		minLogLevel = min
+	}
+	logMu.Unlock()
+	return nil
+}
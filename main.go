@@ -2,55 +2,564 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"sync"
 	"syscall/js"
+	"time"
 )
 
 // JavaScriptから呼び出される add 関数
 func add(this js.Value, args []js.Value) interface{} {
-	if len(args) != 2 {
-		// エラーを返すか、より詳細なエラーオブジェクトを返すことを検討
-		return js.ValueOf("Invalid number of arguments")
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
 	}
-	arg1, ok1 := 安全にIntに変換(args[0])
-	if !ok1 {
-		return js.ValueOf("Argument 1 is not a valid integer")
+	return js.ValueOf(arg1 + arg2)
+}
+
+// JavaScriptから呼び出される subtract 関数
+func subtract(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
 	}
-	arg2, ok2 := 安全にIntに変換(args[1])
-	if !ok2 {
-		return js.ValueOf("Argument 2 is not a valid integer")
+	return js.ValueOf(arg1 - arg2)
+}
+
+// JavaScriptから呼び出される multiply 関数
+func multiply(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
 	}
-	return js.ValueOf(arg1 + arg2)
+	return js.ValueOf(arg1 * arg2)
 }
 
-// js.Valueを安全にintに変換するヘルパー関数
-func 安全にIntに変換(val js.Value) (int, bool) {
-	if val.Type() != js.TypeNumber {
+// JavaScriptから呼び出される divide 関数
+// 結果は小数を含むfloat64として返す (例: 7 / 2 = 3.5)
+// 省略可能な第3引数 { decimals, mode } で結果を丸められる (roundingOption を参照)
+func divide(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	roundResult, err := roundingOption(args, 2)
+	if err != nil {
+		return toJSError(err)
+	}
+	if arg2 == 0 {
+		return newJSError(errCodeDivisionByZero, "Division by zero")
+	}
+	return js.ValueOf(roundResult(float64(arg1) / float64(arg2)))
+}
+
+// JavaScriptから呼び出される divideInt 関数
+// 整数除算を行い、0方向に切り捨てた商を返す (例: 7 / 2 = 3)
+func divideInt(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	if arg2 == 0 {
+		// Goの整数ゼロ除算はpanicしWasmランタイムごと停止するため、事前に弾く
+		return newJSError(errCodeDivisionByZero, "Division by zero")
+	}
+	return js.ValueOf(arg1 / arg2)
+}
+
+// JavaScriptから呼び出される divMod 関数
+// 整数除算の商と余りを { quotient, remainder } として返す
+// 商は0方向に切り捨て、余りの符号は被除数と同じになる (JavaScriptの % と同じ規則)
+func divMod(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	if arg2 == 0 {
+		return newJSError(errCodeDivisionByZero, "Division by zero")
+	}
+	return objectResult(
+		field("quotient", arg1/arg2),
+		field("remainder", arg1%arg2),
+	)
+}
+
+// JavaScriptから呼び出される addFloat 関数
+// add と異なり小数部を切り捨てずに float64 のまま計算する
+// 省略可能な第3引数 { decimals, mode } で結果を丸められる (例: addFloat(0.1, 0.2, { decimals: 2 }) = 0.3)
+func addFloat(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの小数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	roundResult, err := roundingOption(args, 2)
+	if err != nil {
+		return toJSError(err)
+	}
+	sum := arg1 + arg2
+	if math.IsInf(sum, 0) {
+		return newJSError(errCodeNotFinite, "Result overflowed to Infinity")
+	}
+	return js.ValueOf(roundResult(sum))
+}
+
+// JavaScriptから呼び出される addChecked 関数
+// add と異なり、精度を失う入力やオーバーフローする結果をエラーとして返す
+func addChecked(this js.Value, args []js.Value) interface{} {
+	arg1, err := 安全な整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	arg2, err := 安全な整数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	result, ok := checkedAdd(arg1, arg2)
+	// JavaScriptへ返した時点でfloat64に戻るため、安全な整数の範囲を超える結果もオーバーフローとみなす
+	if !ok || result > maxSafeInteger || result < -maxSafeInteger {
+		return newJSError(errCodeOverflow, fmt.Sprintf("Integer overflow: %d + %d", arg1, arg2))
+	}
+	return js.ValueOf(result)
+}
+
+// JavaScriptから呼び出される addVerbose 関数
+// add と同じ計算を行い、Go側で引数をどう解釈したかを合わせて { result, inputsWereIntegers, overflowed } で返す
+//
+//	goWasm.addVerbose(1.9, 2) // => { result: 3, inputsWereIntegers: false, overflowed: false }
+//
+// inputsWereIntegers が false の場合は、add が小数部を切り捨てて計算したことを表す。
+// overflowed が true の場合は、result が Number.MAX_SAFE_INTEGER を超えて正確でないことを表す。
+func addVerbose(this js.Value, args []js.Value) interface{} {
+	arg1, arg2, err := 二つの整数引数を取得(args)
+	if err != nil {
+		return toJSError(err)
+	}
+	integers := true
+	for _, arg := range args[:2] {
+		if num := arg.Float(); math.IsInf(num, 0) || num != math.Trunc(num) {
+			integers = false
+		}
+	}
+	result, ok := checkedAdd(arg1, arg2)
+	overflowed := !ok || result > maxSafeInteger || result < -maxSafeInteger
+	return objectResult(
+		field("result", arg1+arg2),
+		field("inputsWereIntegers", integers),
+		field("overflowed", overflowed),
+	)
+}
+
+// オーバーフローを検出しながら a + b を計算する
+// int の範囲に収まらない場合は ok が false になる
+func checkedAdd(a, b int) (result int, ok bool) {
+	result = a + b
+	// 同符号の加算で結果の符号が反転した場合はオーバーフローしている
+	if (a > 0 && b > 0 && result < 0) || (a < 0 && b < 0 && result >= 0) {
 		return 0, false
 	}
+	return result, true
+}
+
+// JavaScriptから呼び出される sum 関数
+// 任意個の整数を受け取り合計を返す。引数が0個の場合は 0 を返す
+func sum(this js.Value, args []js.Value) interface{} {
+	total := 0
+	for i, arg := range args {
+		num, err := 安全にIntに変換(arg)
+		if err != nil {
+			return newJSError(errCodeOf(err), fmt.Sprintf("Argument at index %d %s", i, errMessageOf(err)))
+		}
+		total += num
+	}
+	return js.ValueOf(total)
+}
+
+// 2つの整数引数を検証して取り出す共通ヘルパー
+// 四則演算の関数はすべてここを通すことで、引数チェックの挙動を揃える
+// 引数の個数と型は登録時の argSpec で検証済みであることを前提とする
+// configure で strictTypes を有効にした場合は、小数部の切り捨てや精度の欠落が起きる値もエラーにする
+func 二つの整数引数を取得(args []js.Value) (arg1, arg2 int, err error) {
+	convert := 安全にIntに変換
+	if currentConfig().strictTypes {
+		convert = 安全な整数に変換
+	}
+	arg1, err = convert(args[0])
+	if err != nil {
+		return 0, 0, newError(errCodeOf(err), "Argument 1 %s", errMessageOf(err))
+	}
+	arg2, err = convert(args[1])
+	if err != nil {
+		return 0, 0, newError(errCodeOf(err), "Argument 2 %s", errMessageOf(err))
+	}
+	return arg1, arg2, nil
+}
+
+// 2つの小数引数を検証して取り出す共通ヘルパー
+func 二つの小数引数を取得(args []js.Value) (arg1, arg2 float64, err error) {
+	arg1, err = 安全にFloatに変換(args[0])
+	if err != nil {
+		return 0, 0, newError(errCodeOf(err), "Argument 1 %s", errMessageOf(err))
+	}
+	arg2, err = 安全にFloatに変換(args[1])
+	if err != nil {
+		return 0, 0, newError(errCodeOf(err), "Argument 2 %s", errMessageOf(err))
+	}
+	return arg1, arg2, nil
+}
+
+// js.Valueを安全にintに変換するヘルパー関数
+func 安全にIntに変換(val js.Value) (int, error) {
+	if err := 値の有無を確認(val); err != nil {
+		return 0, err
+	}
+	if !hasType(val, js.TypeNumber) {
+		return 0, newError(errCodeNotANumber, "is not a valid integer")
+	}
 	num := val.Int()
 	// JavaScriptのNumberはfloat64なので、大きな数値や精度の扱いに注意
-	return num, true
+	return num, nil
+}
+
+// null と undefined を、型の誤りとは別のエラーコード (NULL_ARG) で報告するヘルパー関数
+// 値を渡し忘れた undefined はよくある誤りなので、他の型が渡された場合と区別できるようにする
+func 値の有無を確認(val js.Value) error {
+	if val.IsNull() || val.IsUndefined() {
+		return newError(errCodeNullArg, "is %s", val.Type())
+	}
+	return nil
+}
+
+// JavaScriptの Number.MAX_SAFE_INTEGER (2^53 - 1)
+// これを超える整数はfloat64で正確に表現できない
+const maxSafeInteger = 1<<53 - 1
+
+// js.Valueを精度を失わずにintへ変換するヘルパー関数
+// 小数部を持つ値や Number.MAX_SAFE_INTEGER を超える値は、Int() で黙って丸められる前にエラーにする
+func 安全な整数に変換(val js.Value) (int, error) {
+	if err := 値の有無を確認(val); err != nil {
+		return 0, err
+	}
+	if !hasType(val, js.TypeNumber) {
+		return 0, newError(errCodeNotANumber, "is not a number")
+	}
+	num := val.Float()
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return 0, newError(errCodeNotFinite, "is not a finite number")
+	}
+	if num != math.Trunc(num) {
+		return 0, newError(errCodeNotAnInteger, "is not an integer")
+	}
+	if num > maxSafeInteger || num < -maxSafeInteger {
+		return 0, newError(errCodeOverflow, "exceeds Number.MAX_SAFE_INTEGER")
+	}
+	return int(num), nil
+}
+
+// js.Valueを安全にfloat64に変換するヘルパー関数
+// NaN や Infinity は計算結果を黙って壊すため、数値型であってもエラーとして扱う
+func 安全にFloatに変換(val js.Value) (float64, error) {
+	if err := 値の有無を確認(val); err != nil {
+		return 0, err
+	}
+	if !hasType(val, js.TypeNumber) {
+		return 0, newError(errCodeNotANumber, "is not a number")
+	}
+	num := val.Float()
+	if math.IsNaN(num) {
+		return 0, newError(errCodeNotFinite, "is NaN")
+	}
+	if math.IsInf(num, 0) {
+		return 0, newError(errCodeNotFinite, "is Infinity")
+	}
+	return num, nil
+}
+
+// js.FuncOf に渡すコールバック関数の型
+type callback func(this js.Value, args []js.Value) interface{}
+
+// コールバック内で発生したpanicを回収し、JavaScriptの Error として返すラッパー
+// js.FuncOf のコールバックがpanicするとGoランタイム全体が停止し、
+// ページを再読み込みするまでWasmが使えなくなるため、登録する関数はすべてこれを通す
+func safeWrap(fn callback) callback {
+	return func(this js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				logf(levelError, "Recovered from panic in Go callback: %v", r)
+				result = newJSError(errCodePanic, fmt.Sprintf("Go panic: %v", r))
+			}
+		}()
+		return fn(this, args)
+	}
+}
+
+// JavaScript側で関数をまとめて公開する名前空間オブジェクトの名前
+// globalThis を汚さないよう、すべての関数は globalThis[namespace] のプロパティとして登録する
+const namespace = "goWasm"
+
+var (
+	// 登録済みの js.Func。shutdown 時にまとめて Release する
+	registeredFuncs []js.Func
+	// 登録名から safeWrap 済みのコールバックを引くための表。batch から利用する
+	callbacks = make(map[string]callback)
+	// 登録した関数の定義。listFunctions から利用する
+	// registerCallbacks の定義表を直接参照すると初期化の循環になるため、register で登録順に記録する
+	registeredDefs []exportedFunc
+	// debounce などが実行時に作成して返す js.Func
+	// JavaScript側で release されずに残ったものは shutdown でまとめて Release する
+	dynamicMu    sync.Mutex
+	dynamicFuncs = make(map[*js.Func]struct{})
+	// main関数を待機させるためのチャネル。close すると main が終了する
+	done         = make(chan struct{})
+	shutdownOnce sync.Once
+	// main関数の開始時刻。healthCheck で稼働時間を求めるために使う
+	startTime time.Time
+)
+
+// goWasm に登録する関数の定義
+type exportedFunc struct {
+	// 名前空間オブジェクト上のプロパティ名
+	name string
+	// 受け取る引数の型。fn を呼び出す前に validateArgs で検証する
+	args argSpec
+	// Promise を返す関数かどうか。true の場合、引数の検証エラーは reject された Promise として返す
+	async bool
+	// 同じ引数に対して常に同じ結果を返す純粋関数かどうか。true の場合、結果を resultCache に保持する
+	cacheable bool
+	fn        callback
+}
+
+// 引数の検証を行ってから fn を呼び出すラッパー
+// 各関数は def.args で引数を宣言しておけば、個数や型のチェックを自前で書く必要がない
+// def.cacheable が true の場合は、検証を通った呼び出しだけが withCache による結果キャッシュを通る
+func withValidation(def exportedFunc) callback {
+	fn := def.fn
+	if def.cacheable {
+		fn = withCache(def.name, fn)
+	}
+	return func(this js.Value, args []js.Value) interface{} {
+		if err := validateArgs(args, def.args); err != nil {
+			if def.async {
+				// 非同期関数は同期的に Error を返さず、rejectWithError と同じ形の Error で reject した Promise を返す
+				return js.Global().Get("Promise").Call("reject", toJSError(err))
+			}
+			return toJSError(err)
+		}
+		return fn(this, args)
+	}
+}
+
+// 関数を引数の検証・計測・safeWrap で包んで名前空間オブジェクトに登録する
+func register(ns js.Value, def exportedFunc) {
+	wrapped := safeWrap(withMetrics(def.name, withValidation(def)))
+	f := js.FuncOf(wrapped)
+	registeredFuncs = append(registeredFuncs, f)
+	callbacks[def.name] = wrapped
+	registeredDefs = append(registeredDefs, def)
+	ns.Set(def.name, f)
+}
+
+// 実行時に作成した js.Func を shutdown 時の解放対象に加える
+func trackFunc(f *js.Func) {
+	dynamicMu.Lock()
+	dynamicFuncs[f] = struct{}{}
+	dynamicMu.Unlock()
+}
+
+// 実行時に作成した js.Func を Release し、解放対象から外す
+// 既に解放済みの場合は何もしない
+func releaseFunc(f *js.Func) {
+	dynamicMu.Lock()
+	defer dynamicMu.Unlock()
+	if _, ok := dynamicFuncs[f]; !ok {
+		return
+	}
+	delete(dynamicFuncs, f)
+	f.Release()
+}
+
+// JavaScriptから呼び出される shutdown 関数
+// 登録済みの js.Func をすべて Release し、main関数を終了させる
+// Next.jsの開発時のようにWasmを何度も再インスタンス化する環境で、古いインスタンスのリソースを解放するために使う
+func shutdown(this js.Value, args []js.Value) interface{} {
+	shutdownOnce.Do(func() {
+		// Release 済みの関数が呼ばれないよう、先に名前空間ごと取り除く
+		js.Global().Delete(namespace)
+		for _, f := range registeredFuncs {
+			f.Release()
+		}
+		registeredFuncs = nil
+		dynamicMu.Lock()
+		for f := range dynamicFuncs {
+			f.Release()
+		}
+		dynamicFuncs = make(map[*js.Func]struct{})
+		dynamicMu.Unlock()
+		callbacks = make(map[string]callback)
+		registeredDefs = nil
+		close(done)
+	})
+	return nil
+}
+
+// JavaScriptから呼び出される listFunctions 関数
+// 登録されている関数の一覧を、引数の検証に使う argSpec をもとに登録順の配列で返す
+//
+//	goWasm.listFunctions()
+//	// => [{ name: "add", minArgs: 2, maxArgs: 2, async: false,
+//	//       args: [{ type: "number", optional: false, variadic: false }, ...] }, ...]
+//
+// 可変長引数を受け取る関数は maxArgs が null になる。type は "string|uint8array" のように | 区切りで候補を並べる。
+func listFunctions(this js.Value, args []js.Value) interface{} {
+	list := make([]interface{}, len(registeredDefs))
+	for i, def := range registeredDefs {
+		params := def.args.params()
+		argList := make([]interface{}, len(params))
+		for j, p := range params {
+			argList[j] = objectResult(
+				field("type", p.types),
+				field("optional", p.optional),
+				field("variadic", p.variadic),
+			)
+		}
+		min, max := def.args.arity()
+		var maxArgs interface{}
+		if max >= 0 {
+			maxArgs = max
+		}
+		list[i] = objectResult(
+			field("name", def.name),
+			field("minArgs", min),
+			field("maxArgs", maxArgs),
+			field("async", def.async),
+			field("args", argList),
+		)
+	}
+	return goToJS(list)
+}
+
+// JavaScriptから呼び出される healthCheck 関数
+// Goランタイムが生きていることを確認するための軽量な関数で、ポーリングで呼び出しても負荷にならない
+// panic後などにランタイムが停止していれば、この呼び出し自体がJavaScript側で例外になる
+func healthCheck(this js.Value, args []js.Value) interface{} {
+	return objectResult(
+		field("alive", true),
+		field("uptimeMs", time.Since(startTime).Milliseconds()),
+		field("registeredCallbacks", len(registeredFuncs)),
+	)
 }
 
 // JavaScriptに関数を登録する関数
+// JavaScript側からは goWasm.add(1, 2) のように呼び出す
 func registerCallbacks() {
-	js.Global().Set("goAdd", js.FuncOf(add))
-	// Go側からJavaScriptに準備完了を通知するコールバックを設定することも可能
-	// js.Global().Set("goWasmReady", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-	// 	fmt.Println("Go Wasm is ready to be called from JS!")
-	// 	return nil
-	// }))
+	ns := js.Global().Get("Object").New()
+	for _, def := range []exportedFunc{
+		{name: "add", args: argSpec{"number", "number"}, fn: add},
+		{name: "subtract", args: argSpec{"number", "number"}, fn: subtract},
+		{name: "multiply", args: argSpec{"number", "number"}, fn: multiply},
+		{name: "divide", args: argSpec{"number", "number", "object?"}, fn: divide},
+		{name: "divideInt", args: argSpec{"number", "number"}, fn: divideInt},
+		{name: "divMod", args: argSpec{"number", "number"}, fn: divMod},
+		{name: "addFloat", args: argSpec{"number", "number", "object?"}, fn: addFloat},
+		{name: "addChecked", args: argSpec{"number", "number"}, fn: addChecked},
+		{name: "addVerbose", args: argSpec{"number", "number"}, fn: addVerbose},
+		{name: "round", args: argSpec{"number", "number?", "string?"}, fn: round},
+		{name: "sum", args: argSpec{"...number"}, fn: sum},
+		{name: "sumArray", args: argSpec{"array"}, fn: sumArray},
+		{name: "sort", args: argSpec{"array", "string?"}, fn: sortNumbers},
+		{name: "chunk", args: argSpec{"array", "number"}, fn: chunk},
+		{name: "flatten", args: argSpec{"array", "number"}, fn: flatten},
+		{name: "addAsync", args: argSpec{"number", "number"}, async: true, fn: addAsync},
+		{name: "computeWithTimeout", args: argSpec{"number", "number"}, async: true, fn: computeWithTimeout},
+		{name: "streamPrimes", args: argSpec{"number", "function"}, fn: streamPrimes},
+		{name: "fetch", args: argSpec{"string", "number?"}, async: true, fn: fetchURL},
+		{name: "withRetry", args: argSpec{"function", "number", "number"}, async: true, fn: withRetry},
+		{name: "batch", args: argSpec{"array"}, fn: batch},
+		{name: "deepEqual", args: argSpec{"any", "any"}, fn: deepEqual},
+		{name: "kvSet", args: argSpec{"string", "any"}, fn: kvSet},
+		{name: "kvGet", args: argSpec{"string"}, fn: kvGet},
+		{name: "kvDelete", args: argSpec{"string"}, fn: kvDelete},
+		{name: "kvKeys", args: argSpec{}, fn: kvKeys},
+		{name: "debounce", args: argSpec{"function", "number"}, fn: debounce},
+		{name: "rateLimit", args: argSpec{"function", "number"}, fn: rateLimit},
+		{name: "concat", args: argSpec{"...string"}, fn: concat},
+		{name: "reverse", args: argSpec{"string"}, fn: reverse},
+		{name: "levenshtein", args: argSpec{"string", "string", "boolean?"}, fn: levenshtein},
+		{name: "regexMatch", args: argSpec{"string", "string"}, fn: regexMatch},
+		{name: "regexReplace", args: argSpec{"string", "string", "string"}, fn: regexReplace},
+		{name: "validateEmail", args: argSpec{"string"}, fn: validateEmail},
+		{name: "sha256", args: argSpec{"string|uint8array"}, fn: sha256Hex},
+		{name: "hmac", args: argSpec{"string|uint8array", "string|uint8array"}, fn: hmacHex},
+		{name: "verifyHMAC", args: argSpec{"string|uint8array", "string|uint8array", "string"}, fn: verifyHMAC},
+		{name: "uuid", args: argSpec{"number?"}, fn: uuid},
+		{name: "seedRandom", args: argSpec{"number"}, fn: seedRandom},
+		{name: "random", args: argSpec{}, fn: random},
+		{name: "randomInt", args: argSpec{"number", "number"}, fn: randomInt},
+		{name: "base64Encode", args: argSpec{"uint8array", "string?"}, fn: base64Encode},
+		{name: "base64Decode", args: argSpec{"string", "string?"}, fn: base64Decode},
+		{name: "gzip", args: argSpec{"uint8array", "number?"}, fn: gzipBytes},
+		{name: "gunzip", args: argSpec{"uint8array"}, fn: gunzipBytes},
+		{name: "xorBytes", args: argSpec{"uint8array", "number"}, fn: xorBytes},
+		{name: "processJSON", args: argSpec{"string"}, fn: processJSON},
+		{name: "parseCSV", args: argSpec{"string", "string?"}, fn: parseCSV},
+		{name: "formatTime", args: argSpec{"number", "string", "string?"}, fn: formatTime},
+		{name: "interpolate", args: argSpec{"string", "object", "string?"}, fn: interpolate},
+		{name: "encodeQuery", args: argSpec{"object"}, fn: encodeQuery},
+		{name: "decodeQuery", args: argSpec{"string"}, fn: decodeQuery},
+		{name: "colorConvert", args: argSpec{"string|object", "string", "string"}, fn: colorConvert},
+		{name: "stats", args: argSpec{"array"}, fn: stats},
+		{name: "percentile", args: argSpec{"array", "number|array"}, fn: percentile},
+		{name: "parallelSquare", args: argSpec{"array", "number?"}, fn: parallelSquare},
+		{name: "matMul", args: argSpec{"array", "array"}, fn: matMul},
+		{name: "fib", args: argSpec{"number"}, cacheable: true, fn: fib},
+		{name: "isPrime", args: argSpec{"number"}, fn: isPrime},
+		{name: "factorize", args: argSpec{"number"}, fn: factorize},
+		{name: "addBig", args: argSpec{"bigint|number", "bigint|number"}, fn: addBig},
+		{name: "addMoney", args: argSpec{"string", "string"}, fn: addMoney},
+		{name: "convertBase", args: argSpec{"string", "number", "number"}, fn: convertBase},
+		{name: "toInt32", args: argSpec{"number"}, fn: toInt32},
+		{name: "toUint32", args: argSpec{"number"}, fn: toUint32},
+		{name: "toInt64", args: argSpec{"number|bigint"}, fn: toInt64},
+		{name: "parseIntLoose", args: argSpec{"number|string"}, fn: parseIntLoose},
+		{name: "configure", args: argSpec{"object"}, fn: configure},
+		{name: "setLogHandler", args: argSpec{"function|null", "string?"}, fn: setLogHandler},
+		{name: "clearCache", args: argSpec{}, fn: clearCache},
+		{name: "metrics", args: argSpec{}, fn: metricsInfo},
+		{name: "resetMetrics", args: argSpec{}, fn: resetMetrics},
+		{name: "listFunctions", args: argSpec{}, fn: listFunctions},
+		{name: "memStats", args: argSpec{}, fn: memStats},
+		{name: "forceGC", args: argSpec{}, fn: forceGC},
+		{name: "version", args: argSpec{}, fn: versionInfo},
+		{name: "healthCheck", args: argSpec{}, fn: healthCheck},
+		{name: "shutdown", args: argSpec{}, fn: shutdown},
+	} {
+		register(ns, def)
+	}
+	js.Global().Set(namespace, ns)
+}
+
+// Goの初期化が完了したことをJavaScript側に通知する
+// globalThis.onGoWasmReady が関数として定義されている場合のみ、バージョン文字列を渡して呼び出す
+// 未定義の場合は何もしないため、通知を必要としない呼び出し元でも例外は発生しない
+func notifyReady() {
+	cb := js.Global().Get("onGoWasmReady")
+	if !hasType(cb, js.TypeFunction) {
+		return
+	}
+	// コールバック内でJavaScriptの例外が投げられてもGoランタイムを停止させない
+	defer func() {
+		if r := recover(); r != nil {
+			logf(levelWarn, "onGoWasmReady callback failed: %v", r)
+		}
+	}()
+	cb.Invoke(version)
 }
 
 func main() {
-	c := make(chan struct{}, 0) // プログラムが終了しないようにチャネルを作成
-	fmt.Println("Go WebAssembly Initialized (from Go)")
+	startTime = time.Now()
+	logf(levelInfo, "Go WebAssembly Initialized (from Go)")
 	registerCallbacks()
+	notifyReady()
 
-	// Goの初期化が完了したことをJavaScript側に通知する (オプション)
-	// if js.Global().Get("onGoWasmReady").Type() == js.TypeFunction {
-	// 	js.Global().Call("onGoWasmReady")
-	// }
-
-	<-c // main関数が終了するとWasmインスタンスも終了するため、待機させる
-}
\ No newline at end of file
+	<-done // main関数が終了するとWasmインスタンスも終了するため、shutdown が呼ばれるまで待機させる
+	logf(levelInfo, "Go WebAssembly shut down (from Go)")
+}
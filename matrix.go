@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// 配列の配列を行列 ([][]float64) に変換する
+// 各行の長さが揃っていない場合や、空の行列はエラーにする
+func jsToMatrix(val js.Value) ([][]float64, error) {
+	if !isJSArray(val) {
+		return nil, newError(errCodeNotAnArray, "is not an array of arrays")
+	}
+	if val.Length() == 0 {
+		return nil, newError(errCodeEmptyArray, "must not be an empty matrix")
+	}
+	m := make([][]float64, val.Length())
+	for i := range m {
+		row, err := jsArrayToFloats(val.Index(i))
+		if err != nil {
+			return nil, newError(errCodeOf(err), "row %d %s", i, errMessageOf(err))
+		}
+		if len(row) == 0 {
+			return nil, newError(errCodeEmptyArray, "row %d must not be empty", i)
+		}
+		if i > 0 && len(row) != len(m[0]) {
+			return nil, newError(errCodeDimensionMismatch, "row %d has %d columns, expected %d", i, len(row), len(m[0]))
+		}
+		m[i] = row
+	}
+	return m, nil
+}
+
+// JavaScriptから呼び出される matMul 関数
+// 2つの行列 (数値の配列の配列) を受け取り、積を配列の配列で返す
+// A が n×m、B が m×p のとき、結果は n×p になる
+func matMul(this js.Value, args []js.Value) interface{} {
+	a, err := jsToMatrix(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	b, err := jsToMatrix(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+
+	n, m, p := len(a), len(a[0]), len(b[0])
+	if m != len(b) {
+		return newJSError(errCodeDimensionMismatch, fmt.Sprintf(
+			"Cannot multiply %dx%d by %dx%d: columns of A (%d) must equal rows of B (%d)",
+			n, m, len(b), p, m, len(b)))
+	}
+
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, p)
+		// k を外側に回すことで b の行を連続してアクセスし、キャッシュ効率を上げる
+		for k := 0; k < m; k++ {
+			aik := a[i][k]
+			for j := 0; j < p; j++ {
+				result[i][j] += aik * b[k][j]
+			}
+		}
+	}
+	return goToJS(result)
+}
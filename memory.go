@@ -0,0 +1,30 @@
+package main
+
+import (
+	"runtime"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される memStats 関数
+// runtime.ReadMemStats で取得したGoのメモリ使用量を { alloc, totalAlloc, sys, numGC } で返す (単位はバイト)
+//
+// alloc は現在ヒープ上で使用中のバイト数、totalAlloc はこれまでに割り当てた累計、sys はGoランタイムがOS (Wasmの線形メモリ) から確保した量。
+// 長時間動かすときに alloc が増え続ける場合は、release されていない js.Func やキャッシュの保持を疑う。
+// Wasmの線形メモリは縮まないため、GCで解放されても sys やJavaScript側から見えるメモリサイズは減らない。
+func memStats(this js.Value, args []js.Value) interface{} {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return objectResult(
+		field("alloc", m.Alloc),
+		field("totalAlloc", m.TotalAlloc),
+		field("sys", m.Sys),
+		field("numGC", m.NumGC),
+	)
+}
+
+// JavaScriptから呼び出される forceGC 関数
+// runtime.GC でガベージコレクションを直ちに実行する。前後で memStats を比べ、メモリが回収できるかを確かめるために使う
+func forceGC(this js.Value, args []js.Value) interface{} {
+	runtime.GC()
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// 関数ごとの呼び出し回数と累積実行時間
+type funcMetrics struct {
+	calls int
+	total time.Duration
+}
+
+var (
+	metricsMu sync.Mutex
+	metrics   = make(map[string]*funcMetrics)
+)
+
+// 呼び出しごとに回数と実行時間を記録するラッパー
+// panic した呼び出しも記録するよう、計測は defer で行う
+// Promise を返す関数では、Promise を作成して返すまでの同期部分のみが計測対象になる
+func withMetrics(name string, fn callback) callback {
+	return func(this js.Value, args []js.Value) interface{} {
+		start := time.Now()
+		defer func() {
+			elapsed := time.Since(start)
+			metricsMu.Lock()
+			m, ok := metrics[name]
+			if !ok {
+				m = &funcMetrics{}
+				metrics[name] = m
+			}
+			m.calls++
+			m.total += elapsed
+			metricsMu.Unlock()
+		}()
+		return fn(this, args)
+	}
+}
+
+// JavaScriptから呼び出される metrics 関数
+// 一度以上呼び出された関数ごとに { calls, totalMs, avgMs } を持つオブジェクトを返す
+//
+//	goWasm.metrics() // => { add: { calls: 3, totalMs: 0.12, avgMs: 0.04 }, ... }
+func metricsInfo(this js.Value, args []js.Value) interface{} {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	obj := js.Global().Get("Object").New()
+	for name, m := range metrics {
+		totalMs := float64(m.total) / float64(time.Millisecond)
+		obj.Set(name, objectResult(
+			field("calls", m.calls),
+			field("totalMs", totalMs),
+			field("avgMs", totalMs/float64(m.calls)),
+		))
+	}
+	return obj
+}
+
+// JavaScriptから呼び出される resetMetrics 関数
+// 記録した呼び出し回数と実行時間をすべて破棄する
+func resetMetrics(this js.Value, args []js.Value) interface{} {
+	metricsMu.Lock()
+	metrics = make(map[string]*funcMetrics)
+	metricsMu.Unlock()
+	return nil
+}
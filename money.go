@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"syscall/js"
+)
+
+// 金額として受け付ける文字列の形式。符号と小数部は省略できる
+// 小数部が2桁を超える誤りは別のメッセージで報告するため、ここでは桁数を制限しない
+var moneyPattern = regexp.MustCompile(`^([+-]?)(\d+)(?:\.(\d+))?$`)
+
+// JavaScriptから呼び出される addMoney 関数
+// "10.05" のような10進数の金額文字列を2つ受け取り、和を小数点以下2桁の文字列で返す
+//
+//	goWasm.addMoney("0.10", "0.20") // => "0.30"
+//	goWasm.addMoney("10", "-10.5")  // => "-0.50"
+//
+// 金額は float64 を経由せずに最小単位 (1/100) の整数として計算するため、丸め誤差が生じない。桁数の上限もない。
+// 小数部が3桁以上ある場合や、"1e3" "1,000" " 1" のような形式は INVALID_NUMBER になる。
+func addMoney(this js.Value, args []js.Value) interface{} {
+	a, err := parseMoney(args[0].String())
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	b, err := parseMoney(args[1].String())
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	return js.ValueOf(formatMoney(new(big.Int).Add(a, b)))
+}
+
+// 金額の文字列を最小単位 (1/100) の整数に変換する
+func parseMoney(s string) (*big.Int, error) {
+	m := moneyPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, newError(errCodeInvalidNumber, "is not a valid decimal amount (got %q)", s)
+	}
+	sign, whole, frac := m[1], m[2], m[3]
+	if len(frac) > 2 {
+		return nil, newError(errCodeInvalidNumber, "has more than 2 decimal places (got %q)", s)
+	}
+	n, _ := new(big.Int).SetString(whole+frac+strings.Repeat("0", 2-len(frac)), 10)
+	if sign == "-" {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// 最小単位 (1/100) の整数を小数点以下2桁の文字列にする
+func formatMoney(cents *big.Int) string {
+	sign := ""
+	if cents.Sign() < 0 {
+		sign = "-"
+	}
+	whole, frac := new(big.Int).QuoRem(new(big.Int).Abs(cents), big.NewInt(100), new(big.Int))
+	return fmt.Sprintf("%s%s.%02d", sign, whole, frac.Int64())
+}
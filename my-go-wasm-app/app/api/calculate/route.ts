@@ -13,13 +13,24 @@ interface GoWasmGlobal {
       run(instance: WebAssembly.Instance): Promise<void>;
     };
   };
-  goAdd: (a: number, b: number) => number | string;
+  // Go側の関数はすべて goWasm 名前空間オブジェクトにまとめて登録される
+  goWasm?: {
+    // 不正な入力の場合は code プロパティ付きの Error オブジェクトが返る
+    add: (a: number, b: number) => number | GoWasmError;
+  };
+  // Go側の初期化完了時に呼び出されるコールバック (引数はWasmモジュールのバージョン)
+  onGoWasmReady?: (version: string) => void;
   // Node.js環境では、crypto と performance は globalThis に既に存在しうるが、
   // Wasm実行や特定のライブラリが期待する型と異なる場合があるため、明示的に定義する。
   crypto?: typeof webcrypto; // Node.js の webcrypto と互換性のある型
   performance?: typeof nodePerformance; // Node.js の performance と互換性のある型
 }
 
+// Go側で作成されるエラーオブジェクト (code は "INVALID_ARG_COUNT" や "NOT_A_NUMBER" など)
+interface GoWasmError extends Error {
+  code: string;
+}
+
 // Node.js環境でGo Wasmを実行するために必要なグローバルオブジェクトをセットアップ
 // globalThis が GoWasmGlobal の形状を持つことを TypeScript に伝える
 const g = globalThis as unknown as GoWasmGlobal;
@@ -41,7 +52,7 @@ let wasmInstance: WebAssembly.Instance | null = null; // WebAssemblyのインス
 /**
  * WebAssemblyモジュールを非同期で初期化します。
  * 既に初期化済みの場合は何もしません。
- * この関数は、Wasmモジュール内の 'goAdd' 関数が利用可能になるまで待機します。
+ * この関数は、Go側から onGoWasmReady による準備完了通知が届くまで待機します。
  */
 async function initializeWasm(): Promise<void> {
   if (wasmInstance) {
@@ -76,6 +87,27 @@ async function initializeWasm(): Promise<void> {
 
     console.log('Wasmモジュールがインスタント化されました。');
 
+    // Go側の初期化完了通知 (onGoWasmReady) を待つPromiseを用意
+    // Go側は registerCallbacks の完了後にこのコールバックをバージョン文字列付きで呼び出します。
+    // run() より前に設定しておかないと通知を取りこぼすため、ここで登録します。
+    const ready = new Promise<void>((resolve, reject) => {
+      const timeout = 5000; // タイムアウト時間を5秒に設定
+      const timer = setTimeout(() => {
+        g.onGoWasmReady = undefined;
+        console.error("タイムアウト: Go Wasmから準備完了通知が届きませんでした。");
+        // Wasmの初期化に失敗したとみなし、インスタンスをクリアする
+        wasmInstance = null;
+        reject(new Error("タイムアウト: Go Wasmの準備待機中にエラーが発生しました。Wasmモジュールの初期化に失敗した可能性があります。"));
+      }, timeout);
+
+      g.onGoWasmReady = (version: string) => {
+        clearTimeout(timer);
+        g.onGoWasmReady = undefined;
+        console.log(`Go Wasm (version ${version}) の準備が完了しました。`);
+        resolve();
+      };
+    });
+
     // Goランタイムを開始し、Wasmモジュールを実行
     // これは非同期処理であり、完了を待たずに次の処理に進むことがあります。
     // Wasm内でのエラーはここでキャッチされます。
@@ -85,30 +117,8 @@ async function initializeWasm(): Promise<void> {
       wasmInstance = null; // エラー発生時はインスタンスを無効化
     });
 
-    // 'goAdd'関数がグローバルスコープで利用可能になるまで待機
     // Wasmモジュールの初期化が完了し、Go側でエクスポートされた関数が使えるようになるのを待ちます。
-    await new Promise<void>((resolve, reject) => {
-      const timeout = 5000; // タイムアウト時間を5秒に設定
-      const checkIntervalMs = 50;
-      let elapsedTime = 0;
-
-      const checkInterval = setInterval(() => {
-        if (typeof g.goAdd === 'function') {
-          clearInterval(checkInterval);
-          console.log("'goAdd'関数が利用可能です。");
-          resolve();
-        } else {
-          elapsedTime += checkIntervalMs;
-          if (elapsedTime >= timeout) {
-            clearInterval(checkInterval);
-            console.error("タイムアウト: 'goAdd'関数が利用可能になりませんでした。");
-            // Wasmの初期化に失敗したとみなし、インスタンスをクリアする
-            wasmInstance = null;
-            reject(new Error("タイムアウト: 'goAdd'関数の準備待機中にエラーが発生しました。Wasmモジュールの初期化に失敗した可能性があります。"));
-          }
-        }
-      }, checkIntervalMs);
-    });
+    await ready;
 
     console.log('Go WasmがAPIルート用に初期化されました。');
 
@@ -124,16 +134,16 @@ async function initializeWasm(): Promise<void> {
 // APIリクエストを処理するPOSTハンドラ
 export async function POST(request: Request): Promise<NextResponse> {
   try {
-    // Wasmモジュールが初期化されているか、または 'goAdd' 関数が利用可能かを確認
-    if (!wasmInstance || typeof g.goAdd !== 'function') {
-      console.log("Wasmが未初期化または 'goAdd' が利用不可のため、初期化処理を実行します...");
+    // Wasmモジュールが初期化されているか、または 'goWasm.add' 関数が利用可能かを確認
+    if (!wasmInstance || typeof g.goWasm?.add !== 'function') {
+      console.log("Wasmが未初期化または 'goWasm.add' が利用不可のため、初期化処理を実行します...");
       await initializeWasm(); // Wasmモジュールを初期化
     }
 
-    // 初期化後、再度 'goAdd' 関数の存在を確認
+    // 初期化後、再度 'goWasm.add' 関数の存在を確認
     // initializeWasm内でエラーが発生した場合、wasmInstanceはnullになっているはず
-    if (!wasmInstance || typeof g.goAdd !== 'function') {
-      console.error("Wasmモジュールの準備ができていないか、'goAdd'関数が見つかりません（初期化試行後）。");
+    if (!wasmInstance || typeof g.goWasm?.add !== 'function') {
+      console.error("Wasmモジュールの準備ができていないか、'goWasm.add'関数が見つかりません（初期化試行後）。");
       return NextResponse.json(
         { error: "Wasmモジュールが利用できません。サーバー管理者にお問い合わせください。" },
         { status: 503 } // Service Unavailable
@@ -152,12 +162,12 @@ export async function POST(request: Request): Promise<NextResponse> {
       );
     }
 
-    // Wasmモジュール内の 'goAdd' 関数を呼び出し
-    const result = g.goAdd(a, b);
+    // Wasmモジュール内の 'goWasm.add' 関数を呼び出し
+    const result = g.goWasm!.add(a, b);
 
-    // Go側で不正な入力として処理された場合の対応 (例: "Invalid input: ...")
-    if (typeof result === 'string' && result.startsWith("Invalid")) {
-        return NextResponse.json({ error: result }, { status: 400 }); // Bad Request
+    // Go側で不正な入力として処理された場合の対応 (Error オブジェクトが返る)
+    if (result instanceof Error) {
+        return NextResponse.json({ error: result.message, code: result.code }, { status: 400 }); // Bad Request
     }
 
     // 計算結果をJSON形式でレスポンス
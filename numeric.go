@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"syscall/js"
+)
+
+// fib が受け付ける n の上限
+// 結果の桁数は n に比例して増えるため、1回の呼び出しでWasmを長時間占有しないよう制限する
+const maxFibN = 50000
+
+// JavaScriptから呼び出される fib 関数
+// n番目のフィボナッチ数 (fib(0) = 0, fib(1) = 1) を反復計算で求める
+// 再帰を使わないため、大きな n でもスタックを消費しない
+//
+// 結果が Number.MAX_SAFE_INTEGER 以下なら number を、それを超える場合は精度を保つため BigInt を返す
+func fib(this js.Value, args []js.Value) interface{} {
+	n, err := 安全な整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	if n < 0 {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 1 must not be negative (got %d)", n))
+	}
+	if n > maxFibN {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 1 must be at most %d (got %d)", maxFibN, n))
+	}
+
+	// 安全な整数の範囲に収まる間はintで計算する
+	a, b := 0, 1
+	i := 0
+	for ; i < n; i++ {
+		if b > maxSafeInteger-a {
+			break
+		}
+		a, b = b, a+b
+	}
+	if i == n {
+		return js.ValueOf(a)
+	}
+
+	// 範囲を超えたら math/big に切り替えて続きを計算する
+	x, y := big.NewInt(int64(a)), big.NewInt(int64(b))
+	for ; i < n; i++ {
+		x.Add(x, y)
+		x, y = y, x
+	}
+	if x.IsInt64() && x.Int64() <= maxSafeInteger {
+		return js.ValueOf(x.Int64())
+	}
+	return bigIntToJS(x)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"syscall/js"
+)
+
+// parallelSquare の並列数の既定値と上限
+const (
+	defaultConcurrency = 4
+	maxConcurrency     = 64
+)
+
+// JavaScriptから呼び出される parallelSquare 関数
+// 数値の配列を concurrency 個 (省略時は defaultConcurrency) のgoroutineからなるワーカープールで処理し、
+// 各要素を2乗した配列を元の順序のまま返す
+//
+//	goWasm.parallelSquare([1, 2, 3], 2) // => [1, 4, 9]
+//
+// 結果が Infinity になる要素があった場合は、そのうち最も小さいインデックスの要素のエラーを返す。
+//
+// 並列性について: 標準の js/wasm はシングルスレッドで GOMAXPROCS は常に1のため、
+// ワーカーのgoroutineは同じスレッド上で交互に実行されるだけで、concurrency を増やしても計算は速くならない。
+// 処理の分割方法はマルチスレッドの環境と同じなので、要素ごとの計算を差し替えるための出発点として使う。
+func parallelSquare(this js.Value, args []js.Value) interface{} {
+	nums, err := jsArrayToFloats(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	concurrency := defaultConcurrency
+	if arg, ok := optionalArg(args, 1); ok {
+		if concurrency, err = 安全な整数に変換(arg); err != nil {
+			return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+		}
+		if concurrency < 1 || concurrency > maxConcurrency {
+			return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must be between 1 and %d (got %d)", maxConcurrency, concurrency))
+		}
+	}
+
+	results, err := parallelMap(nums, concurrency, func(x float64) (float64, error) {
+		sq := x * x
+		if math.IsInf(sq, 0) {
+			return 0, newError(errCodeNotFinite, "result overflowed to Infinity")
+		}
+		return sq, nil
+	})
+	if err != nil {
+		return toJSError(err)
+	}
+	return goToJS(results)
+}
+
+// nums の各要素に fn を適用した結果を、concurrency 個のワーカーで並行に求める
+// 結果は入力と同じ順序で返す。失敗した要素がある場合は、最も小さいインデックスのエラーを返す
+//
+// ワーカーはJavaScriptの値に触れないため、fn の中で syscall/js を使わないこと。
+func parallelMap(nums []float64, concurrency int, fn func(float64) (float64, error)) ([]float64, error) {
+	results := make([]float64, len(nums))
+	errs := make([]error, len(nums))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = fn(nums[i])
+			}
+		}()
+	}
+	for i := range nums {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, newError(errCodeOf(err), "Element at index %d: %s", i, errMessageOf(err))
+		}
+	}
+	return results, nil
+}
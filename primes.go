@@ -0,0 +1,161 @@
+package main
+
+import (
+	"math/bits"
+	"sort"
+	"syscall/js"
+)
+
+// 決定的なミラー・ラビン判定に使う底
+// 最初の12個の素数を底にすると 2^64 未満のすべての整数を正しく判定できる
+var millerRabinBases = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// JavaScriptから呼び出される isPrime 関数
+// 正の整数 n が素数かどうかを返す
+func isPrime(this js.Value, args []js.Value) interface{} {
+	n, err := 正の整数引数を取得(args[0])
+	if err != nil {
+		return toJSError(err)
+	}
+	return js.ValueOf(isPrimeUint64(uint64(n)))
+}
+
+// JavaScriptから呼び出される factorize 関数
+// 正の整数 n を素因数分解し、素因数を重複を含めて昇順に並べた配列を返す (factorize(12) => [2, 2, 3])
+// n が 1 の場合は空の配列を返す
+func factorize(this js.Value, args []js.Value) interface{} {
+	n, err := 正の整数引数を取得(args[0])
+	if err != nil {
+		return toJSError(err)
+	}
+	factors := primeFactors(uint64(n))
+	sort.Slice(factors, func(i, j int) bool { return factors[i] < factors[j] })
+	return goToJS(factors)
+}
+
+// isPrime と factorize の引数を正の安全な整数として取り出す
+// 負の数と 0 は素数判定や素因数分解の対象にならないため OUT_OF_RANGE にする
+func 正の整数引数を取得(val js.Value) (int, error) {
+	n, err := 安全な整数に変換(val)
+	if err != nil {
+		return 0, newError(errCodeOf(err), "Argument 1 %s", errMessageOf(err))
+	}
+	if n <= 0 {
+		return 0, newError(errCodeOutOfRange, "Argument 1 must be a positive integer (got %d)", n)
+	}
+	return n, nil
+}
+
+// n が素数かどうかを小さな素数での試し割りと決定的なミラー・ラビン判定で求める
+func isPrimeUint64(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	// n-1 = d * 2^s に分解する
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+	for _, a := range millerRabinBases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for r := 1; r < s; r++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// n の素因数を順不同で返す
+// 小さな因数は試し割りで取り除き、残りはポラードのロー法で分解する
+func primeFactors(n uint64) []uint64 {
+	var factors []uint64
+	for _, p := range millerRabinBases {
+		for n%p == 0 {
+			factors = append(factors, p)
+			n /= p
+		}
+	}
+	var split func(m uint64)
+	split = func(m uint64) {
+		if m == 1 {
+			return
+		}
+		if isPrimeUint64(m) {
+			factors = append(factors, m)
+			return
+		}
+		d := pollardRho(m)
+		split(d)
+		split(m / d)
+	}
+	split(n)
+	return factors
+}
+
+// 合成数 n の自明でない約数を1つ返す (フロイドの循環検出によるポラードのロー法)
+// n は小さな素因数を取り除いた奇数の合成数であることを前提とする
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod(x, x, n) + c) % n }
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			d = gcdUint64(absDiff(x, y), n)
+		}
+		// d == n の場合は周期に入っただけなので、定数 c を変えてやり直す
+		if d != n {
+			return d
+		}
+	}
+}
+
+// (a * b) mod m をオーバーフローさせずに計算する
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi%m, lo, m)
+	return rem
+}
+
+// (base ^ exp) mod m を繰り返し二乗法で計算する
+func powMod(base, exp, m uint64) uint64 {
+	result := uint64(1)
+	base %= m
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+		exp >>= 1
+	}
+	return result
+}
+
+func gcdUint64(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される encodeQuery 関数
+// オブジェクトを net/url.Values でURLエンコードしたクエリ文字列 (先頭の ? は含まない) に変換する
+//
+//	goWasm.encodeQuery({ q: "東京 駅", tag: ["a", "b"], page: 2 })
+//	// => "page=2&q=%E6%9D%B1%E4%BA%AC+%E9%A7%85&tag=a&tag=b"
+//
+// 値には string、number、boolean、BigInt と、それらの配列を使える。配列は同じキーの繰り返しになる。
+// 値が null または undefined のキーは出力しない。キーは url.Values.Encode と同じく辞書順に並ぶ。
+func encodeQuery(this js.Value, args []js.Value) interface{} {
+	obj := args[0]
+	values := url.Values{}
+	keys := js.Global().Get("Object").Call("keys", obj)
+	for i := 0; i < keys.Length(); i++ {
+		key := keys.Index(i).String()
+		val := obj.Get(key)
+		if val.IsNull() || val.IsUndefined() {
+			continue
+		}
+		if !isJSArray(val) {
+			s, err := queryValueString(val)
+			if err != nil {
+				return newJSError(errCodeOf(err), fmt.Sprintf("Property %q %s", key, errMessageOf(err)))
+			}
+			values.Add(key, s)
+			continue
+		}
+		for j := 0; j < val.Length(); j++ {
+			s, err := queryValueString(val.Index(j))
+			if err != nil {
+				return newJSError(errCodeOf(err), fmt.Sprintf("Property %q at index %d %s", key, j, errMessageOf(err)))
+			}
+			values.Add(key, s)
+		}
+	}
+	return js.ValueOf(values.Encode())
+}
+
+// クエリの値にできるスカラー値を、JavaScriptの String() と同じ表記の文字列にする
+func queryValueString(val js.Value) (string, error) {
+	switch {
+	case hasType(val, js.TypeString):
+		return val.String(), nil
+	case hasType(val, js.TypeNumber), hasType(val, js.TypeBoolean), isBigInt(val):
+		return js.Global().Get("String").Invoke(val).String(), nil
+	default:
+		return "", newError(errCodeWrongType, "is not a string, number, boolean or BigInt (got %s)", typeName(val))
+	}
+}
+
+// JavaScriptから呼び出される decodeQuery 関数
+// クエリ文字列を url.ParseQuery で解析し、キーごとの値を持つオブジェクトを返す
+//
+//	goWasm.decodeQuery("tag=a&q=%E6%9D%B1%E4%BA%AC&tag=b") // => { q: "東京", tag: ["a", "b"] }
+//
+// 1回だけ現れるキーは文字列、繰り返し現れるキーは現れた順の文字列の配列になる。
+// 先頭の ? は取り除いてから解析する。不正なパーセントエンコーディングや ; を含む場合は INVALID_QUERY を返す。
+func decodeQuery(this js.Value, args []js.Value) interface{} {
+	query := args[0].String()
+	if len(query) > 0 && query[0] == '?' {
+		query = query[1:]
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return newJSError(errCodeInvalidQuery, fmt.Sprintf("Malformed query string: %v", err))
+	}
+
+	// 表示順を安定させるため、キーは辞書順に定義する
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fields := make([]resultField, len(keys))
+	for i, k := range keys {
+		if vs := values[k]; len(vs) == 1 {
+			fields[i] = field(k, vs[0])
+		} else {
+			fields[i] = field(k, vs)
+		}
+	}
+	return objectResult(fields...)
+}
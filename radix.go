@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される convertBase 関数
+// fromBase 進数で書かれた整数の文字列を toBase 進数の文字列に変換する (基数は2から36)
+//
+//	goWasm.convertBase("ff", 16, 2)   // => "11111111"
+//	goWasm.convertBase("-101", 2, 10) // => "-5"
+//
+// 値は int64 の範囲 (-9223372036854775808 から 9223372036854775807) まで扱え、範囲外は OVERFLOW になる。
+// 10より大きい基数の桁には英字を使い、入力の大文字小文字は区別しない。出力は小文字になる。
+func convertBase(this js.Value, args []js.Value) interface{} {
+	value := args[0].String()
+	fromBase, err := 基数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	toBase, err := 基数に変換(args[2])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 3 "+errMessageOf(err))
+	}
+
+	n, err := strconv.ParseInt(value, fromBase, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return newJSError(errCodeOverflow, fmt.Sprintf("Value %q in base %d is out of the int64 range", value, fromBase))
+		}
+		return newJSError(errCodeInvalidNumber, fmt.Sprintf("Value %q is not a valid base %d integer", value, fromBase))
+	}
+	return js.ValueOf(strconv.FormatInt(n, toBase))
+}
+
+// 基数を表す引数を検証して取り出す
+func 基数に変換(val js.Value) (int, error) {
+	base, err := 安全な整数に変換(val)
+	if err != nil {
+		return 0, err
+	}
+	if base < 2 || base > 36 {
+		return 0, newError(errCodeOutOfRange, "must be between 2 and 36 (got %d)", base)
+	}
+	return base, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+var (
+	// random と randomInt が使う疑似乱数の生成器
+	// *rand.Rand は並行に使えないため randMu で保護する
+	randMu  sync.Mutex
+	randGen = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// JavaScriptから呼び出される seedRandom 関数
+// random と randomInt が使う疑似乱数の生成器を seed で初期化し直す
+// 同じ seed を与えれば、その後に得られる値の列は実行のたびに (ブラウザやOSが違っても) 同じになる
+//
+//	goWasm.seedRandom(42);
+//	goWasm.random(); goWasm.randomInt(1, 6); // 毎回同じ結果になる
+//
+// 起動直後は現在時刻で初期化されている。
+// 再現性のための生成器なので、暗号用途には使わないこと (uuid などは crypto/rand を使う)。
+func seedRandom(this js.Value, args []js.Value) interface{} {
+	seed, err := 安全な整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	randMu.Lock()
+	randGen = rand.New(rand.NewSource(int64(seed)))
+	randMu.Unlock()
+	return nil
+}
+
+// JavaScriptから呼び出される random 関数
+// 0以上1未満の疑似乱数を返す
+func random(this js.Value, args []js.Value) interface{} {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return js.ValueOf(randGen.Float64())
+}
+
+// JavaScriptから呼び出される randomInt 関数
+// min 以上 max 以下 (両端を含む) の整数の疑似乱数を返す
+func randomInt(this js.Value, args []js.Value) interface{} {
+	lo, err := 安全な整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	hi, err := 安全な整数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	if lo > hi {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 1 must not be greater than argument 2 (got %d > %d)", lo, hi))
+	}
+	randMu.Lock()
+	defer randMu.Unlock()
+	// 両端が安全な整数なので、幅は int64 に収まる
+	return js.ValueOf(lo + int(randGen.Int63n(int64(hi-lo)+1)))
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"regexp"
+	"sync"
+	"syscall/js"
+)
+
+// コンパイル済み正規表現をキャッシュする個数の上限
+// 上限に達したらキャッシュを空にしてから追加する (頻繁に使うパターンはすぐに再びキャッシュされる)
+const maxRegexCache = 128
+
+var (
+	regexMu    sync.Mutex
+	regexCache = make(map[string]*regexp.Regexp)
+)
+
+// パターンをコンパイルする。同じパターンはキャッシュしたものを再利用する
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	if re, ok := regexCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, newError(errCodeInvalidPattern, "Invalid regular expression: %v", err)
+	}
+	if len(regexCache) >= maxRegexCache {
+		regexCache = make(map[string]*regexp.Regexp)
+	}
+	regexCache[pattern] = re
+	return re, nil
+}
+
+// JavaScriptから呼び出される regexMatch 関数
+// GoのRE2構文の正規表現 pattern が input にマッチするかを真偽値で返す
+// RE2は後方参照などを持たない代わりに、入力長に対して線形時間でのマッチを保証する
+func regexMatch(this js.Value, args []js.Value) interface{} {
+	re, err := compileRegex(args[0].String())
+	if err != nil {
+		return toJSError(err)
+	}
+	return js.ValueOf(re.MatchString(args[1].String()))
+}
+
+// JavaScriptから呼び出される regexReplace 関数
+// input 中で pattern にマッチした部分をすべて replacement に置き換えた文字列を返す
+// replacement 中の $1 や ${name} はキャプチャグループの内容に展開される (regexp.Regexp.Expand と同じ規則)
+func regexReplace(this js.Value, args []js.Value) interface{} {
+	re, err := compileRegex(args[0].String())
+	if err != nil {
+		return toJSError(err)
+	}
+	return js.ValueOf(re.ReplaceAllString(args[1].String(), args[2].String()))
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"syscall/js"
+	"time"
+)
+
+// withRetry の試行回数と待ち時間の上限
+const (
+	maxRetryAttempts = 100
+	maxRetryDelay    = 30 * time.Second
+)
+
+// JavaScriptから呼び出される withRetry 関数
+// fn を呼び出し、例外を投げるか返した Promise が reject された場合は待ち時間を増やしながら最大 maxAttempts 回まで呼び直す
+// 結果は Promise で返し、いずれかの試行が成功した時点でその値で resolve する
+//
+//	goWasm.withRetry(() => goWasm.fetch("/api/data", 1000), 3, 200)
+//	// 失敗すると約200ms、約400ms待って再試行し、3回とも失敗したら reject
+//
+// n 回目の失敗の後は baseDelayMs * 2^(n-1) (上限 maxRetryDelay) を基準に、その半分から等倍までの範囲でランダムに揺らした時間だけ待つ。
+// 揺らぎを入れることで、複数の呼び出しが同時に失敗したときに再試行のタイミングが揃わないようにする。
+//
+// すべての試行が失敗した場合は、code が "RETRY_EXHAUSTED" で、試行回数の attempts プロパティと
+// 最後の失敗の理由を持つ cause プロパティを持つ Error で reject する。
+func withRetry(this js.Value, args []js.Value) interface{} {
+	return newPromise(func(resolve, reject js.Value) {
+		fn := args[0]
+		maxAttempts, err := 安全な整数に変換(args[1])
+		if err != nil {
+			rejectWithError(reject, errCodeOf(err), "Argument 2 "+errMessageOf(err))
+			return
+		}
+		if maxAttempts < 1 || maxAttempts > maxRetryAttempts {
+			rejectWithError(reject, errCodeOutOfRange, fmt.Sprintf("Argument 2 must be between 1 and %d (got %d)", maxRetryAttempts, maxAttempts))
+			return
+		}
+		baseDelayMs, err := 安全な整数に変換(args[2])
+		if err != nil {
+			rejectWithError(reject, errCodeOf(err), "Argument 3 "+errMessageOf(err))
+			return
+		}
+		if baseDelayMs < 0 || int64(baseDelayMs) > maxRetryDelay.Milliseconds() {
+			rejectWithError(reject, errCodeOutOfRange, fmt.Sprintf("Argument 3 must be between 0 and %d (got %d)", maxRetryDelay.Milliseconds(), baseDelayMs))
+			return
+		}
+		baseDelay := time.Duration(baseDelayMs) * time.Millisecond
+
+		var reason js.Value
+		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			// Promise.resolve().then(fn) を通すと、fn が投げた例外も返した Promise の reject と同じく拒否として受け取れる
+			value, why, ok := awaitPromise(js.Global().Get("Promise").Call("resolve").Call("then", fn))
+			if ok {
+				resolve.Invoke(value)
+				return
+			}
+			reason = why
+			if attempt < maxAttempts {
+				delay := retryDelay(baseDelay, attempt)
+				logf(levelDebug, "withRetry attempt %d failed, retrying in %v", attempt, delay)
+				time.Sleep(delay)
+			}
+		}
+
+		errVal := newJSError(errCodeRetryExhausted, fmt.Sprintf("Failed after %d attempts: %s", maxAttempts, reasonMessage(reason)))
+		errVal.Set("attempts", maxAttempts)
+		errVal.Set("cause", reason)
+		reject.Invoke(errVal)
+	})
+}
+
+// attempt 回目の失敗の後に待つ時間を、指数的に増やした時間の半分から等倍までの範囲で求める
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxRetryDelay)
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// 拒否の理由をエラーメッセージに含めるための文字列にする
+// Error なら message を、それ以外の値は String() で変換した文字列を使う
+func reasonMessage(reason js.Value) string {
+	if hasType(reason, js.TypeObject) && reason.InstanceOf(js.Global().Get("Error")) {
+		return reason.Get("message").String()
+	}
+	return js.Global().Get("String").Invoke(reason).String()
+}
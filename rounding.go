@@ -0,0 +1,147 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"syscall/js"
+)
+
+// 丸めモード
+type roundingMode string
+
+const (
+	// 0.5 ちょうどの場合は0から遠い方へ丸める (四捨五入。2.5 => 3, -2.5 => -3)
+	roundHalfUp roundingMode = "halfUp"
+	// 0.5 ちょうどの場合は偶数の方へ丸める (銀行家の丸め。2.5 => 2, 3.5 => 4)
+	roundHalfEven roundingMode = "halfEven"
+)
+
+// モードを指定しない場合に使う丸めモードの初期値。configure の roundingMode で変更できる
+const defaultRoundingMode = roundHalfUp
+
+// 丸める小数点以下の桁数の上限。Number.prototype.toFixed と同じ
+const maxRoundDecimals = 100
+
+// 文字列から丸めモードを取得する。"banker" は "halfEven" の別名として受け付ける
+func parseRoundingMode(s string) (roundingMode, error) {
+	switch mode := roundingMode(s); mode {
+	case roundHalfUp, roundHalfEven:
+		return mode, nil
+	case "banker":
+		return roundHalfEven, nil
+	default:
+		return "", newError(errCodeInvalidOption, "Unknown rounding mode %q: expected %q or %q", s, roundHalfUp, roundHalfEven)
+	}
+}
+
+// v を小数点以下 decimals 桁に丸める
+//
+// 2進数の float64 のまま10のべき乗を掛けて丸めると 1.005 が 1.00 になるような誤差が出るため、
+// v をJavaScriptでの表示と同じ最短の10進表現に直し、その10進数に対して丸める。
+// NaN と ±Infinity はそのまま返す。
+func roundTo(v float64, decimals int, mode roundingMode) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	r.Mul(r, new(big.Rat).SetInt(scale))
+
+	// 絶対値で丸めてから符号を戻す
+	neg := r.Sign() < 0
+	r.Abs(r)
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	// rem / denom と 1/2 を比べる
+	switch new(big.Int).Lsh(rem, 1).Cmp(r.Denom()) {
+	case 1:
+		q.Add(q, big.NewInt(1))
+	case 0:
+		if mode == roundHalfUp || q.Bit(0) == 1 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if neg {
+		q.Neg(q)
+	}
+	f, _ := new(big.Rat).SetFrac(q, scale).Float64()
+	if neg && f == 0 {
+		// -0.001 を丸めた結果も -0 ではなく 0 にそろえる
+		return 0
+	}
+	return f
+}
+
+// 丸めの桁数を検証する
+func checkRoundDecimals(decimals int) error {
+	if decimals < 0 || decimals > maxRoundDecimals {
+		return newError(errCodeOutOfRange, "must be between 0 and %d (got %d)", maxRoundDecimals, decimals)
+	}
+	return nil
+}
+
+// JavaScriptから呼び出される round 関数
+// 数値を小数点以下 decimals 桁 (省略時は 0) に丸める。第3引数で丸めモードを指定できる
+//
+//	goWasm.round(0.1 + 0.2, 2)          // => 0.3
+//	goWasm.round(2.675, 2)              // => 2.68 ("halfUp")
+//	goWasm.round(2.5, 0, "halfEven")    // => 2
+//
+// モードの既定値は "halfUp" (0.5 ちょうどは0から遠い方へ丸める四捨五入)。"halfEven" は銀行家の丸め。
+// 既定値は configure の roundingMode で変更できる。
+func round(this js.Value, args []js.Value) interface{} {
+	value, err := 安全にFloatに変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	decimals := 0
+	if arg, ok := optionalArg(args, 1); ok {
+		if decimals, err = 安全な整数に変換(arg); err == nil {
+			err = checkRoundDecimals(decimals)
+		}
+		if err != nil {
+			return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+		}
+	}
+	mode := currentConfig().roundingMode
+	if arg, ok := optionalArg(args, 2); ok {
+		if mode, err = parseRoundingMode(arg.String()); err != nil {
+			return toJSError(err)
+		}
+	}
+	return js.ValueOf(roundTo(value, decimals, mode))
+}
+
+// 小数を返す四則演算の関数が受け取る、省略可能な丸めオプションを読み取る
+//
+//	goWasm.addFloat(0.1, 0.2, { decimals: 2, mode: "halfEven" }) // => 0.3
+//
+// decimals は必須、mode は省略すると configure で設定した既定の丸めモード (初期値は "halfUp") になる。
+// オプションが渡されなかった場合は値をそのまま返す関数を返す。
+func roundingOption(args []js.Value, index int) (func(float64) float64, error) {
+	opts, ok := optionalArg(args, index)
+	if !ok {
+		return func(v float64) float64 { return v }, nil
+	}
+	decimalsVal := opts.Get("decimals")
+	if decimalsVal.IsUndefined() {
+		return nil, newError(errCodeInvalidOption, "Argument %d has no \"decimals\" property", index+1)
+	}
+	decimals, err := 安全な整数に変換(decimalsVal)
+	if err == nil {
+		err = checkRoundDecimals(decimals)
+	}
+	if err != nil {
+		return nil, newError(errCodeOf(err), "Argument %d property \"decimals\" %s", index+1, errMessageOf(err))
+	}
+	mode := currentConfig().roundingMode
+	if modeVal := opts.Get("mode"); !modeVal.IsUndefined() {
+		if !hasType(modeVal, js.TypeString) {
+			return nil, newError(errCodeNotAString, "Argument %d property \"mode\" is not a string (got %s)", index+1, typeName(modeVal))
+		}
+		if mode, err = parseRoundingMode(modeVal.String()); err != nil {
+			return nil, err
+		}
+	}
+	return func(v float64) float64 { return roundTo(v, decimals, mode) }, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"syscall/js"
+)
+
+// JavaScriptから呼び出される stats 関数
+// 数値の配列を受け取り、{ mean, median, min, max, stddev } を持つオブジェクトを返す
+// stddev は母標準偏差 (n で割る) である
+func stats(this js.Value, args []js.Value) interface{} {
+	nums, err := jsArrayToFloats(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	if len(nums) == 0 {
+		return newJSError(errCodeEmptyArray, "Argument 1 must not be an empty array")
+	}
+
+	// 元の配列の順序は保ったまま、中央値を求めるためにコピーを並べ替える
+	sorted := append([]float64(nil), nums...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, n := range sorted {
+		total += n
+	}
+	mean := total / float64(len(sorted))
+
+	variance := 0.0
+	for _, n := range sorted {
+		variance += (n - mean) * (n - mean)
+	}
+	variance /= float64(len(sorted))
+
+	return objectResult(
+		field("mean", mean),
+		field("median", median(sorted)),
+		field("min", sorted[0]),
+		field("max", sorted[len(sorted)-1]),
+		field("stddev", math.Sqrt(variance)),
+	)
+}
+
+// ソート済みの空でないスライスの中央値を返す
+// 要素数が偶数の場合は中央の2つの平均をとる
+func median(sorted []float64) float64 {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// JavaScriptから呼び出される percentile 関数
+// 数値の配列の p パーセンタイル (0 <= p <= 100) を、順位の間を線形補間して求める
+// p に配列を渡すと、各 p のパーセンタイルを同じ順序の配列で返す
+//
+//	goWasm.percentile([1, 2, 3, 4], 50)        // => 2.5
+//	goWasm.percentile([1, 2, 3, 4], [0, 100])  // => [1, 4]
+//
+// 補間の方法は Excel の PERCENTILE.INC や NumPy の既定と同じで、ソート後の (n-1) * p / 100 番目の位置の値をとる。
+func percentile(this js.Value, args []js.Value) interface{} {
+	nums, err := jsArrayToFloats(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	if len(nums) == 0 {
+		return newJSError(errCodeEmptyArray, "Argument 1 must not be an empty array")
+	}
+
+	batch := isJSArray(args[1])
+	var ps []float64
+	if batch {
+		if ps, err = jsArrayToFloats(args[1]); err != nil {
+			return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+		}
+	} else {
+		p, err := 安全にFloatに変換(args[1])
+		if err != nil {
+			return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+		}
+		ps = []float64{p}
+	}
+	for i, p := range ps {
+		if p >= 0 && p <= 100 {
+			continue
+		}
+		if batch {
+			return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 element at index %d must be between 0 and 100 (got %v)", i, p))
+		}
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must be between 0 and 100 (got %v)", p))
+	}
+
+	sorted := append([]float64(nil), nums...)
+	sort.Float64s(sorted)
+	results := make([]float64, len(ps))
+	for i, p := range ps {
+		results[i] = interpolatePercentile(sorted, p)
+	}
+	if !batch {
+		return js.ValueOf(results[0])
+	}
+	return goToJS(results)
+}
+
+// ソート済みの空でないスライスの p パーセンタイルを線形補間で求める
+func interpolatePercentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"syscall/js"
+	"time"
+)
+
+// streamPrimes が計算をどのくらい続けたらJavaScriptのイベントループに実行を譲るか
+const streamYieldInterval = 10 * time.Millisecond
+
+// JavaScriptから呼び出される streamPrimes 関数
+// limit 以下の素数を見つけるたびに onValue を呼び出し、最後に完了を通知する
+// 結果を1つの巨大な配列にまとめずに、見つかった順に受け取れる
+//
+//	const stream = goWasm.streamPrimes(1_000_000, (r) => {
+//	  if (r.done) { console.log(r.count, r.cancelled); return; }
+//	  console.log(r.value);
+//	});
+//	stream.cancel(); // 途中で打ち切る
+//
+// onValue は素数ごとに { value, done: false } を、最後に一度だけ { done: true, count, cancelled } を受け取る。
+// 返り値の cancel を呼ぶか、onValue が false を返すか例外を投げると計算を打ち切り、cancelled: true で完了を通知する。
+//
+// onValue は streamPrimes が返った後に非同期で呼ばれる。streamPrimes の呼び出し中に最初の値が届くことはないため、
+// 返り値を受け取る前に onValue から cancel を参照してしまうことはない。
+//
+// 計算は goroutine 上で行う。js/wasm はシングルスレッドで、goroutine は他に実行できるものが無くなるまでJavaScriptに制御を返さない。
+// そのため goroutine の開始時に time.Sleep で一度イベントループに制御を返し、streamPrimes の呼び出しを先に完了させる。
+// また計算中はイベントループが止まり cancel も呼べないため、streamYieldInterval ごとに time.Sleep で実行を譲る。
+func streamPrimes(this js.Value, args []js.Value) interface{} {
+	limit, err := 安全な整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	if limit < 0 || limit > maxComputeLimit {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 1 must be between 0 and %d (got %d)", maxComputeLimit, limit))
+	}
+	onValue := args[1]
+
+	var cancelled atomic.Bool
+	var cancel js.Func
+	cancel = js.FuncOf(func(this js.Value, _ []js.Value) interface{} {
+		cancelled.Store(true)
+		return nil
+	})
+	trackFunc(&cancel)
+	handle := objectResult(field("cancel", cancel.Value))
+
+	go func() {
+		// time.Sleep は setTimeout で再開するため、ここで待つ間に streamPrimes がJavaScriptへ戻る
+		time.Sleep(time.Millisecond)
+		count := emitPrimes(limit, &cancelled, func(p int) bool {
+			ret, ok := invokeCallback(onValue, objectResult(field("value", p), field("done", false)))
+			return ok && !(hasType(ret, js.TypeBoolean) && !ret.Bool())
+		})
+		// 完了後に呼ばれても解放済みの関数を呼ばないよう、何もしない関数に差し替えてから解放する
+		handle.Set("cancel", js.Global().Get("Function").New())
+		releaseFunc(&cancel)
+		invokeCallback(onValue, objectResult(field("done", true), field("count", count), field("cancelled", cancelled.Load())))
+	}()
+	return handle
+}
+
+// limit 以下の素数をエラトステネスの篩で昇順に求め、見つかるたびに emit を呼び出す
+// emit が false を返すか cancelled が立つと打ち切る。それまでに emit した個数を返す
+func emitPrimes(limit int, cancelled *atomic.Bool, emit func(int) bool) int {
+	if limit < 2 {
+		return 0
+	}
+	composite := make([]bool, limit+1)
+	count := 0
+	lastYield := time.Now()
+	for i := 2; i <= limit; i++ {
+		if i%cancelCheckInterval == 0 && time.Since(lastYield) >= streamYieldInterval {
+			time.Sleep(time.Millisecond)
+			lastYield = time.Now()
+		}
+		if cancelled.Load() {
+			return count
+		}
+		if composite[i] {
+			continue
+		}
+		count++
+		if !emit(i) {
+			cancelled.Store(true)
+			return count
+		}
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+	}
+	return count
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+	"unicode"
+)
+
+// JavaScriptから呼び出される concat 関数
+// 任意個の文字列を受け取り、連結した文字列を返す。引数が0個の場合は空文字列を返す
+func concat(this js.Value, args []js.Value) interface{} {
+	var b strings.Builder
+	for _, arg := range args {
+		b.WriteString(arg.String())
+	}
+	return js.ValueOf(b.String())
+}
+
+// JavaScriptから呼び出される reverse 関数
+// 文字列をrune単位で反転する。バイト単位で反転すると日本語などのマルチバイト文字が壊れるため
+// 注意: 結合文字 (濁点の合成など) や絵文字の異体字セレクタは別々のruneとして反転される
+func reverse(this js.Value, args []js.Value) interface{} {
+	runes := []rune(args[0].String())
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return js.ValueOf(string(runes))
+}
+
+// levenshtein が受け付ける文字列の長さ (rune数) の上限
+// 計算量は2つの長さの積に比例するため、1回の呼び出しでWasmを長時間占有しないよう制限する
+const maxLevenshteinLen = 10000
+
+// JavaScriptから呼び出される levenshtein 関数
+// 2つの文字列の編集距離 (挿入・削除・置換の最小回数) をrune単位で求める
+//
+//	goWasm.levenshtein("kitten", "sitting")       // => 3
+//	goWasm.levenshtein("東京都", "京都府")         // => 2
+//	goWasm.levenshtein(" Hello ", "hello", true)  // => 0
+//
+// 第3引数に true を渡すと、比較の前に前後の空白を取り除き、大文字小文字を同一視する (Unicodeのケースフォールディング)。
+// 使うメモリは短い方の文字列の長さに比例する。どちらかが maxLevenshteinLen を超える場合は OUT_OF_RANGE を返す。
+func levenshtein(this js.Value, args []js.Value) interface{} {
+	a, b := args[0].String(), args[1].String()
+	if normalize, ok := optionalArg(args, 2); ok && normalize.Bool() {
+		a, b = foldString(strings.TrimSpace(a)), foldString(strings.TrimSpace(b))
+	}
+	ra, rb := []rune(a), []rune(b)
+	for i, r := range [][]rune{ra, rb} {
+		if len(r) > maxLevenshteinLen {
+			return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument %d must be at most %d characters (got %d)", i+1, maxLevenshteinLen, len(r)))
+		}
+	}
+	return js.ValueOf(editDistance(ra, rb))
+}
+
+// 大文字小文字を区別せずに比較するため、各runeをケースフォールディングの最小のruneにそろえる
+func foldString(s string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, s)
+}
+
+// a と b の編集距離を、直前の行だけを保持する動的計画法で求める
+func editDistance(a, b []rune) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
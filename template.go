@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"text/template"
+)
+
+// {{key}} や {{.user.name}} のような、値を1つ参照するだけのプレースホルダ
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\.?)([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\s*\}\}`)
+
+// 先頭に . の無い名前のうち、text/template の予約語や組み込み関数として解釈しなければならないもの
+var templateKeywords = map[string]bool{
+	"if": true, "else": true, "end": true, "range": true, "with": true, "define": true,
+	"template": true, "block": true, "break": true, "continue": true, "nil": true,
+	"and": true, "or": true, "not": true, "len": true, "index": true, "slice": true, "call": true,
+	"print": true, "printf": true, "println": true, "html": true, "js": true, "urlquery": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+}
+
+// JavaScriptから呼び出される interpolate 関数
+// テンプレート文字列の {{key}} を data の値で置き換えた文字列を返す
+//
+//	goWasm.interpolate("Hello, {{name}}!", { name: "Go" })                          // => "Hello, Go!"
+//	goWasm.interpolate("{{user.name}} ({{age}})", { user: { name: "A" } }, "empty") // => "A ()"
+//
+// 描画には text/template を使う。{{key}} と {{a.b}} は data の値を参照するプレースホルダとして扱い、
+// それ以外は {{if .x}}...{{end}} や {{range .items}} を含め text/template の構文をそのまま使える
+// (プレースホルダ以外の場所では、text/template と同じく {{if .x}} のように . を付けて参照する)。
+//
+// 第3引数は data に無いキーを参照したときの動作で、"error" (既定値) は code が "MISSING_KEY" の Error を返し、
+// "empty" は空文字列を出力する。値が null のキーはどちらの場合も空文字列になる。
+// 構文の誤りは INVALID_TEMPLATE を返す。
+func interpolate(this js.Value, args []js.Value) interface{} {
+	missingKey := "error"
+	var option string
+	if arg, ok := optionalArg(args, 2); ok {
+		missingKey = arg.String()
+	}
+	switch missingKey {
+	case "error":
+		option = "missingkey=error"
+	case "empty":
+		option = "missingkey=zero"
+	default:
+		return newJSError(errCodeInvalidOption, fmt.Sprintf("Unknown missing-key mode %q: expected \"error\" or \"empty\"", missingKey))
+	}
+
+	data, err := jsToGo(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+
+	// プレースホルダは lookup の呼び出しに書き換える
+	// text/template の missingkey=zero は存在しないキーを "<no value>" と出力し、{{a.b}} の a が無い場合はエラーになるため、
+	// キーをたどる処理を自前で行い、見つからなかったキーを記録する
+	var missing string
+	lookup := func(data interface{}, path ...string) (interface{}, error) {
+		v := data
+		for i, key := range path {
+			m, ok := v.(map[string]interface{})
+			if ok {
+				v, ok = m[key]
+			}
+			if !ok {
+				if missingKey == "empty" {
+					return "", nil
+				}
+				missing = strings.Join(path[:i+1], ".")
+				return nil, fmt.Errorf("no value for key %q", missing)
+			}
+		}
+		if v == nil {
+			return "", nil
+		}
+		return v, nil
+	}
+	src := placeholderPattern.ReplaceAllStringFunc(args[0].String(), func(m string) string {
+		sub := placeholderPattern.FindStringSubmatch(m)
+		dot, name := sub[1], sub[2]
+		if dot == "" && templateKeywords[name] {
+			return m
+		}
+		var call strings.Builder
+		call.WriteString("{{lookup .")
+		for _, key := range strings.Split(name, ".") {
+			call.WriteString(" " + strconv.Quote(key))
+		}
+		call.WriteString("}}")
+		return call.String()
+	})
+	tmpl, err := template.New("interpolate").Funcs(template.FuncMap{"lookup": lookup}).Option(option).Parse(src)
+	if err != nil {
+		return newJSError(errCodeInvalidTemplate, fmt.Sprintf("Malformed template: %v", err))
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		if missing != "" {
+			return newJSError(errCodeMissingKey, fmt.Sprintf("Missing template key %q", missing))
+		}
+		if strings.Contains(err.Error(), "map has no entry for key") {
+			return newJSError(errCodeMissingKey, fmt.Sprintf("Missing template key: %v", err))
+		}
+		return newJSError(errCodeInvalidTemplate, fmt.Sprintf("Template execution failed: %v", err))
+	}
+	return js.ValueOf(b.String())
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+	"time"
+
+	// js/wasm ではOSのタイムゾーンデータベースを参照できないため、IANAタイムゾーンデータをバイナリに埋め込む
+	// (Wasmのサイズが約450KB増える)
+	_ "time/tzdata"
+)
+
+// formatTime でレイアウト文字列の代わりに指定できる、time パッケージ定義済みのレイアウト名
+var namedLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// JavaScriptから呼び出される formatTime 関数
+// UNIX時刻 (ミリ秒) をGoのレイアウト文字列で整形した文字列を返す
+// レイアウトはGoの基準時刻 "2006-01-02 15:04:05" を使う形式か、"RFC3339" などの定義済みレイアウト名で指定する
+// 省略可能な第3引数でIANAタイムゾーン名 ("Asia/Tokyo" など) を指定できる。既定値は "UTC"
+//
+//	goWasm.formatTime(Date.now(), "2006年01月02日 15:04", "Asia/Tokyo")
+func formatTime(this js.Value, args []js.Value) interface{} {
+	ms, err := 安全な整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+
+	layout := args[1].String()
+	if named, ok := namedLayouts[layout]; ok {
+		layout = named
+	}
+	if err := checkLayout(layout); err != nil {
+		return toJSError(err)
+	}
+
+	loc := time.UTC
+	if tzArg, ok := optionalArg(args, 2); ok {
+		loc, err = time.LoadLocation(tzArg.String())
+		if err != nil {
+			return newJSError(errCodeInvalidTimezone, fmt.Sprintf("Unknown time zone %q: %v", tzArg.String(), err))
+		}
+	}
+	return js.ValueOf(time.UnixMilli(int64(ms)).In(loc).Format(layout))
+}
+
+// レイアウト文字列が時刻の要素を1つでも含むかを確認する
+// time.Format は未知の文字列をそのまま出力するためエラーにならないが、
+// 異なる2つの時刻を整形して結果が変わらなければ、基準時刻の要素を含まない誤ったレイアウトとみなす
+func checkLayout(layout string) error {
+	if layout == "" {
+		return newError(errCodeInvalidLayout, "Layout must not be empty")
+	}
+	t1 := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	t2 := time.Date(2012, 11, 22, 16, 17, 18, 0, time.UTC)
+	if t1.Format(layout) == t2.Format(layout) {
+		return newError(errCodeInvalidLayout, "Layout %q contains no time elements (use Go reference time, e.g. \"2006-01-02 15:04:05\")", layout)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// JavaScriptから呼び出される debounce 関数
+// fn を ms ミリ秒だけ遅らせて呼び出すラッパー関数を返す
+// 待機中にラッパーが再び呼ばれるとタイマーをリセットし、最後の呼び出しの引数で1回だけ fn を呼び出す
+//
+//	const onInput = goWasm.debounce((q) => search(q), 300);
+//	onInput("a"); onInput("ab"); // 300ms後に search("ab") が1回だけ呼ばれる
+//	onInput.release();          // 不要になったら必ず解放する
+//
+// ラッパーは js.Func なので、使い終わったら release プロパティの関数を呼んで解放すること。
+// release は待機中の呼び出しも取り消す。解放し忘れたものは shutdown 時に解放される。
+func debounce(this js.Value, args []js.Value) interface{} {
+	fn := args[0]
+	ms, err := 安全な整数に変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	if ms < 0 {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must not be negative (got %d)", ms))
+	}
+	delay := time.Duration(ms) * time.Millisecond
+
+	var (
+		mu    sync.Mutex
+		timer *time.Timer
+	)
+	wrapper := js.FuncOf(func(this js.Value, callArgs []js.Value) interface{} {
+		// 呼び出し元の引数を fn に渡すため、待機中に書き換えられないようコピーしておく
+		pending := make([]interface{}, len(callArgs))
+		for i, a := range callArgs {
+			pending[i] = a
+		}
+		mu.Lock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(delay, func() {
+			invokeCallback(fn, pending...)
+		})
+		mu.Unlock()
+		return nil
+	})
+	trackFunc(&wrapper)
+
+	var release js.Func
+	release = js.FuncOf(func(this js.Value, _ []js.Value) interface{} {
+		mu.Lock()
+		if timer != nil {
+			timer.Stop()
+		}
+		mu.Unlock()
+		releaseFunc(&wrapper)
+		releaseFunc(&release)
+		return nil
+	})
+	trackFunc(&release)
+
+	wrapper.Set("release", release)
+	return wrapper.Value
+}
+
+// JavaScriptから呼び出される rateLimit 関数
+// fn の呼び出しを1秒あたり最大 callsPerSecond 回に制限するラッパー関数を返す
+// 上限を超えた呼び出しは fn を実行せず、すぐに code が "RATE_LIMITED" の Error を返す
+//
+//	const onKey = goWasm.rateLimit((q) => goWasm.sha256(q), 5);
+//	const r = onKey("abc"); // 1秒に5回を超えると r は Error になる
+//	onKey.release();
+//
+// トークンバケット方式で、バケットは callsPerSecond 個 (1未満の場合は1個) のトークンで満たされた状態から始まる。
+// そのため短時間のまとまった呼び出しも容量までは許可される。
+// fn が例外を投げた場合は、その例外がそのままラッパーの呼び出し元へ投げられる。
+// ラッパーは内部で debounce と同様に js.Func を保持するので、使い終わったら release を呼んで解放すること。
+func rateLimit(this js.Value, args []js.Value) interface{} {
+	fn := args[0]
+	rate, err := 安全にFloatに変換(args[1])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 2 "+errMessageOf(err))
+	}
+	if rate <= 0 {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 2 must be positive (got %v)", rate))
+	}
+	bucket := newTokenBucket(rate)
+
+	// Go側の js.FuncOf からはJavaScriptの例外を投げられないため、fn の例外は captureCall で結果として受け取り、
+	// rethrowWrapper が作る外側のJavaScript関数で投げ直す。safeWrap は Go 側の panic だけを PANIC の Error にする
+	inner := js.FuncOf(safeWrap(func(this js.Value, callArgs []js.Value) interface{} {
+		if !bucket.take() {
+			return objectResult(field("value", newJSError(errCodeRateLimited, fmt.Sprintf("Rate limit of %v calls per second exceeded", rate))))
+		}
+		passed := make([]interface{}, len(callArgs))
+		for i, a := range callArgs {
+			passed[i] = a
+		}
+		return captureCall.Invoke(fn, this, passed)
+	}))
+	trackFunc(&inner)
+	wrapper := rethrowWrapper.Invoke(inner)
+
+	var release js.Func
+	release = js.FuncOf(func(this js.Value, _ []js.Value) interface{} {
+		releaseFunc(&inner)
+		releaseFunc(&release)
+		return nil
+	})
+	trackFunc(&release)
+
+	wrapper.Set("release", release)
+	return wrapper
+}
+
+// rateLimit のラッパーで、包んだ関数の例外をそのまま呼び出し元へ伝えるためのJavaScript側の補助関数
+var (
+	// fn を呼び出し、戻り値を { value } で、例外を { threw: true, error } で返す
+	captureCall = js.Global().Get("Function").New("fn", "self", "args",
+		"try { return { value: fn.apply(self, args) }; } catch (e) { return { threw: true, error: e }; }")
+	// captureCall の結果を返す Go の関数 inner を包み、例外だった場合は投げ直す関数を返す
+	// inner が Error を返した場合は Go 側の panic を safeWrap が変換したものなので、そのまま返す
+	rethrowWrapper = js.Global().Get("Function").New("inner",
+		"return function (...args) { const r = inner.apply(this, args); if (r instanceof Error) return r; if (r.threw) throw r.error; return r.value; };")
+)
+
+// rateLimit で使うトークンバケット
+// 呼び出しのたびに前回からの経過時間に応じてトークンを補充するため、補充用のタイマーは不要
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	capacity := math.Max(rate, 1)
+	return &tokenBucket{rate: rate, capacity: capacity, tokens: capacity, last: time.Now()}
+}
+
+// トークンを1つ消費する。トークンが足りない場合は false を返す
+func (b *tokenBucket) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// goroutine上からJavaScriptのコールバックを呼び出す
+// goroutine内では safeWrap が効かないため、コールバックが例外を投げてもランタイムが停止しないようここで回収する
+// 例外が発生した場合は ok が false になる
+func invokeCallback(fn js.Value, args ...interface{}) (result js.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			logf(levelError, "JavaScript callback threw: %v", r)
+			result, ok = js.Undefined(), false
+		}
+	}()
+	return fn.Invoke(args...), true
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+// js.Valueを範囲チェック付きでint32に変換するヘルパー関数
+func 安全にInt32に変換(val js.Value) (int32, error) {
+	n, err := 安全な整数に変換(val)
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return 0, newError(errCodeOutOfRange, "is out of int32 range (got %d)", n)
+	}
+	return int32(n), nil
+}
+
+// js.Valueを範囲チェック付きでuint32に変換するヘルパー関数
+// 負の値と 2^32-1 を超える値はエラーにする
+func 安全にUint32に変換(val js.Value) (uint32, error) {
+	n, err := 安全な整数に変換(val)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > math.MaxUint32 {
+		return 0, newError(errCodeOutOfRange, "is out of uint32 range (got %d)", n)
+	}
+	return uint32(n), nil
+}
+
+// js.Valueをint64に変換するヘルパー関数
+// Number は精度を失わない安全な整数のみ、BigInt は int64 の範囲に収まるもののみ受け付ける
+func 安全にInt64に変換(val js.Value) (int64, error) {
+	if isBigInt(val) {
+		n, err := 安全にBigIntに変換(val)
+		if err != nil {
+			return 0, err
+		}
+		if !n.IsInt64() {
+			return 0, newError(errCodeOutOfRange, "is out of int64 range (got %s)", n)
+		}
+		return n.Int64(), nil
+	}
+	n, err := 安全な整数に変換(val)
+	if err != nil {
+		return 0, err
+	}
+	return int64(n), nil
+}
+
+// 1引数の型変換関数の共通処理
+func typedConversion(args []js.Value, convert func(js.Value) (interface{}, error)) interface{} {
+	v, err := convert(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	return goToJS(v)
+}
+
+// JavaScriptから呼び出される toInt32 関数
+// 引数が int32 の範囲の整数であればその値を返し、そうでなければ Error を返す
+func toInt32(this js.Value, args []js.Value) interface{} {
+	return typedConversion(args, func(val js.Value) (interface{}, error) {
+		return 安全にInt32に変換(val)
+	})
+}
+
+// JavaScriptから呼び出される toUint32 関数
+// 引数が uint32 の範囲の整数であればその値を返し、そうでなければ Error を返す
+func toUint32(this js.Value, args []js.Value) interface{} {
+	return typedConversion(args, func(val js.Value) (interface{}, error) {
+		return 安全にUint32に変換(val)
+	})
+}
+
+// JavaScriptから呼び出される toInt64 関数
+// 引数が int64 の範囲の整数であれば、安全な整数の範囲内なら number、それを超えるなら BigInt で返す
+func toInt64(this js.Value, args []js.Value) interface{} {
+	return typedConversion(args, func(val js.Value) (interface{}, error) {
+		n, err := 安全にInt64に変換(val)
+		if err != nil {
+			return nil, err
+		}
+		if n > maxSafeInteger || n < -maxSafeInteger {
+			return big.NewInt(n), nil
+		}
+		return n, nil
+	})
+}
+
+// 数値または整数を表す文字列を安全な整数に変換するヘルパー関数
+// 文字列は前後の空白を取り除いてから strconv.Atoi で解釈し、その場合は coerced が true になる
+// "0x10" や "1e3"、"12px" のようにJavaScriptの Number() や parseInt() では数値になりうる文字列も受け付けない
+func 緩やかに整数に変換(val js.Value) (n int, coerced bool, err error) {
+	if !hasType(val, js.TypeString) {
+		n, err = 安全な整数に変換(val)
+		return n, false, err
+	}
+	s := strings.TrimSpace(val.String())
+	n, err = strconv.Atoi(s)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, true, newError(errCodeOverflow, "exceeds Number.MAX_SAFE_INTEGER (got %q)", s)
+		}
+		return 0, true, newError(errCodeInvalidNumber, "is not an integer string (got %q)", s)
+	}
+	if n > maxSafeInteger || n < -maxSafeInteger {
+		return 0, true, newError(errCodeOverflow, "exceeds Number.MAX_SAFE_INTEGER (got %q)", s)
+	}
+	return n, true, nil
+}
+
+// JavaScriptから呼び出される parseIntLoose 関数
+// 整数の number に加えて整数を表す文字列も受け付け、{ value, coerced } を返す
+//
+//	goWasm.parseIntLoose(42)       // => { value: 42, coerced: false }
+//	goWasm.parseIntLoose(" -7 \n") // => { value: -7, coerced: true }
+//	goWasm.parseIntLoose("12px")   // => Error (code: "INVALID_NUMBER")
+//
+// 他の関数は文字列を数値として扱わないため、文字列の入力を受け付けたいときだけこれで明示的に変換する。
+func parseIntLoose(this js.Value, args []js.Value) interface{} {
+	n, coerced, err := 緩やかに整数に変換(args[0])
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	return objectResult(field("value", n), field("coerced", coerced))
+}
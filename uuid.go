@@ -0,0 +1,50 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"syscall/js"
+)
+
+// uuid が1回の呼び出しで生成できる個数の上限
+const maxUUIDBatch = 10000
+
+// crypto/rand を使ってランダムなUUID (バージョン4) を生成し、ハイフン区切りの正規形で返す
+func newUUIDv4() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", newError(errCodeRandomFailure, "crypto/rand failed: %v", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // バージョン4
+	b[8] = (b[8] & 0x3f) | 0x80 // バリアント (RFC 4122)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// JavaScriptから呼び出される uuid 関数
+// 引数なしの場合はUUIDを1つ文字列で返す
+// 個数を渡した場合 (goWasm.uuid(10) など) は、境界をまたぐ回数を減らすためUUIDの配列をまとめて返す
+func uuid(this js.Value, args []js.Value) interface{} {
+	countArg, ok := optionalArg(args, 0)
+	if !ok {
+		id, err := newUUIDv4()
+		if err != nil {
+			return toJSError(err)
+		}
+		return js.ValueOf(id)
+	}
+
+	count, err := 安全な整数に変換(countArg)
+	if err != nil {
+		return newJSError(errCodeOf(err), "Argument 1 "+errMessageOf(err))
+	}
+	if count < 0 || count > maxUUIDBatch {
+		return newJSError(errCodeOutOfRange, fmt.Sprintf("Argument 1 must be between 0 and %d (got %d)", maxUUIDBatch, count))
+	}
+	ids := make([]string, count)
+	for i := range ids {
+		if ids[i], err = newUUIDv4(); err != nil {
+			return toJSError(err)
+		}
+	}
+	return goToJS(ids)
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+)
+
+// 関数が受け取る引数の型の宣言
+// 要素ごとに1つの引数の型を表し、次の型名を使える
+//
+//	"number" "string" "boolean" "bigint" "function" "array" "object" "uint8array" "null" "any"
+//
+// "string|uint8array" のように | で区切るといずれかの型を受け付ける ("null" は null と undefined の両方に一致する)。
+// 末尾に ? を付けた引数 ("number?") は省略可能で、undefined を渡した場合も省略とみなす。
+// 最後の要素だけは先頭に ... を付けて ("...number") 残りの引数すべてに同じ型を要求できる。
+type argSpec []string
+
+// 引数の個数の下限と上限を返す。可変長の場合、上限は -1 になる
+func (spec argSpec) arity() (min, max int) {
+	for _, s := range spec {
+		switch {
+		case strings.HasPrefix(s, "..."):
+			return min, -1
+		case strings.HasSuffix(s, "?"):
+		default:
+			min++
+		}
+	}
+	return min, len(spec)
+}
+
+// 個数の条件を "2"、"1 or 2"、"1 to 3"、"at least 1" のように表す
+func (spec argSpec) arityString() string {
+	min, max := spec.arity()
+	switch {
+	case max < 0:
+		return fmt.Sprintf("at least %d", min)
+	case min == max:
+		return fmt.Sprintf("%d", min)
+	case max == min+1:
+		return fmt.Sprintf("%d or %d", min, max)
+	default:
+		return fmt.Sprintf("%d to %d", min, max)
+	}
+}
+
+// spec の1要素を、型名と省略可能・可変長の指定に分解したもの
+type argParam struct {
+	types    string
+	optional bool
+	variadic bool
+}
+
+// spec を要素ごとに分解する
+func (spec argSpec) params() []argParam {
+	params := make([]argParam, len(spec))
+	for i, s := range spec {
+		p := &params[i]
+		if strings.HasPrefix(s, "...") {
+			p.variadic = true
+			s = strings.TrimPrefix(s, "...")
+		}
+		if strings.HasSuffix(s, "?") {
+			p.optional = true
+			s = strings.TrimSuffix(s, "?")
+		}
+		p.types = s
+	}
+	return params
+}
+
+// 引数が spec に従っているかを検証する
+// 個数が合わない場合は INVALID_ARG_COUNT、型が合わない場合は期待した型に応じたコードで、最初に見つかった不一致を返す
+// 必須の引数に null や undefined が渡された場合は、型の誤りと区別して NULL_ARG を返す
+func validateArgs(args []js.Value, spec argSpec) error {
+	min, max := spec.arity()
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return newError(errCodeInvalidArgCount, "Invalid number of arguments: expected %s, got %d", spec.arityString(), len(args))
+	}
+	for i, arg := range args {
+		t := spec[len(spec)-1]
+		if i < len(spec) {
+			t = spec[i]
+		}
+		t = strings.TrimPrefix(t, "...")
+		if strings.HasSuffix(t, "?") {
+			if arg.IsUndefined() {
+				continue
+			}
+			t = strings.TrimSuffix(t, "?")
+		}
+		if !matchesType(arg, t) {
+			if arg.IsNull() || arg.IsUndefined() {
+				return newError(errCodeNullArg, "Argument %d is %s (expected %s)", i+1, arg.Type(), withArticle(t))
+			}
+			return newError(typeErrorCode(t), "Argument %d is not %s (got %s)", i+1, withArticle(t), typeName(arg))
+		}
+	}
+	return nil
+}
+
+// 値が型名 (| 区切りの候補を含む) のいずれかに一致するかを判定する
+func matchesType(val js.Value, types string) bool {
+	for _, t := range strings.Split(types, "|") {
+		var ok bool
+		switch t {
+		case "any":
+			ok = true
+		case "null":
+			ok = val.IsNull() || val.IsUndefined()
+		case "number":
+			ok = hasType(val, js.TypeNumber)
+		case "string":
+			ok = hasType(val, js.TypeString)
+		case "boolean":
+			ok = hasType(val, js.TypeBoolean)
+		case "function":
+			ok = hasType(val, js.TypeFunction)
+		case "bigint":
+			ok = isBigInt(val)
+		case "array":
+			ok = isJSArray(val)
+		case "uint8array":
+			ok = isUint8Array(val)
+		case "object":
+			ok = hasType(val, js.TypeObject) && !isJSArray(val)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// 型の不一致を表すエラーコードを、期待した型 (候補が複数ある場合は先頭) から決める
+func typeErrorCode(types string) string {
+	switch strings.Split(types, "|")[0] {
+	case "number":
+		return errCodeNotANumber
+	case "string":
+		return errCodeNotAString
+	case "bigint":
+		return errCodeNotABigInt
+	case "array":
+		return errCodeNotAnArray
+	case "object":
+		return errCodeNotAnObject
+	default:
+		return errCodeWrongType
+	}
+}
+
+// エラーメッセージ用に "a string" や "a string or uint8array" のような表現を作る
+func withArticle(types string) string {
+	alts := strings.Split(types, "|")
+	for i, t := range alts {
+		switch t {
+		case "null":
+			alts[i] = "null"
+		case "any":
+			alts[i] = "any value"
+		case "array", "object":
+			alts[i] = "an " + t
+		default:
+			alts[i] = "a " + t
+		}
+	}
+	return strings.Join(alts, " or ")
+}
+
+// エラーメッセージ用に値の型名を返す
+// js.Type では区別できない配列・BigInt・Uint8Array も個別の名前にする
+func typeName(val js.Value) string {
+	switch {
+	case isJSArray(val):
+		return "array"
+	case isBigInt(val):
+		return "bigint"
+	case isUint8Array(val):
+		return "uint8array"
+	default:
+		return val.Type().String()
+	}
+}
+
+// 省略可能な引数を取り出す
+// 引数が渡されていないか undefined の場合は ok が false になる
+func optionalArg(args []js.Value, index int) (val js.Value, ok bool) {
+	if index >= len(args) || args[index].IsUndefined() {
+		return js.Undefined(), false
+	}
+	return args[index], true
+}
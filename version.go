@@ -0,0 +1,29 @@
+package main
+
+import (
+	"runtime"
+	"syscall/js"
+)
+
+// ビルド情報
+// -ldflags "-X" で上書きできるよう、定数ではなくパッケージ変数として定義する (-X は string 型の変数にのみ作用する)
+//
+//	GOOS=js GOARCH=wasm go build \
+//		-ldflags "-X main.version=1.2.3 -X main.buildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+//		-o main.go.wasm .
+var (
+	// Wasmモジュールのバージョン
+	version = "0.1.0"
+	// ビルド日時 (RFC 3339)。指定されなかった場合は "unknown"
+	buildTime = "unknown"
+)
+
+// JavaScriptから呼び出される version 関数
+// ブラウザにキャッシュされた複数のWasmのうち、どれが読み込まれているかを確認するために使う
+func versionInfo(this js.Value, args []js.Value) interface{} {
+	return objectResult(
+		field("version", version),
+		field("goVersion", runtime.Version()),
+		field("buildTime", buildTime),
+	)
+}